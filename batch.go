@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/render"
+)
+
+// Output formats
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+// modelFile is the name of the file the model is written to by formatJSON
+const modelFile = "docsonnet.json"
+
+// target is a library to document, along with where to
+type target struct {
+	file      string
+	output    string
+	urlPrefix string
+	// byName appends the package name to output and urlPrefix, to tell
+	// libraries sharing them apart
+	byName bool
+}
+
+// targets expands the glob patterns of `entries`. Libraries are written to
+// `output` and linked below `urlPrefix`, or the subdirectory configured for
+// their entrypoint. Without one, a subdirectory named after the package is
+// used if there are multiple libraries.
+func targets(entries []entrypoint, output, urlPrefix string) ([]target, error) {
+	var out []target
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		files, err := expandGlobs([]string{e.File})
+		if err != nil {
+			return nil, err
+		}
+
+		t := target{output: output, urlPrefix: urlPrefix}
+		if e.Output != "" {
+			t.output = filepath.Join(output, e.Output)
+			t.urlPrefix = path.Join(urlPrefix, filepath.ToSlash(e.Output))
+		}
+		if e.URLPrefix != "" {
+			t.urlPrefix = e.URLPrefix
+		}
+		t.byName = len(files) > 1 || (e.Output == "" && e.URLPrefix == "" && len(entries) > 1)
+
+		for _, f := range files {
+			if seen[f] {
+				continue
+			}
+			seen[f] = true
+
+			t.file = f
+			out = append(out, t)
+		}
+	}
+
+	if len(out) == 1 && entries[0].Output == "" && entries[0].URLPrefix == "" {
+		out[0].byName = false
+	}
+	return out, nil
+}
+
+// expandGlobs replaces glob patterns in `args` by the files they match
+func expandGlobs(args []string) ([]string, error) {
+	var files []string
+	for _, a := range args {
+		matches, err := filepath.Glob(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", a, err)
+		}
+		if len(matches) == 0 {
+			// not a pattern, or matching nothing. Either way, loading it
+			// reports a proper error
+			matches = []string{a}
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// loadTargets loads the libraries of `targets` concurrently
+func loadTargets(targets []target, concurrency int, opts docsonnet.Opts) []docsonnet.Result {
+	files := make([]string, len(targets))
+	for i, t := range targets {
+		files[i] = t.file
+	}
+
+	log.Printf("Loading %d libraries", len(files))
+	return docsonnet.LoadAll(files, concurrency, opts)
+}
+
+// loaded logs the diagnostics and error of `r`, and reports whether its
+// package can be used
+func loaded(r docsonnet.Result, lint docsonnet.LintRules) bool {
+	logDocUtil(r.File, r.DocUtil, r.DocUtilErr)
+
+	diags := lint.Apply(r.Diagnostics)
+	for _, d := range diags {
+		log.Printf("%s: %s", r.File, d)
+	}
+	if n := diags.Errors(); n > 0 {
+		log.Printf("%s: %d diagnostics are errors", r.File, n)
+		return false
+	}
+	if r.Err != nil {
+		log.Printf("%s: %s", r.File, r.Err)
+		return false
+	}
+	return true
+}
+
+// writeResults writes the packages of `results` to their respective target in
+// the given formats, unless loading them failed
+func writeResults(targets []target, results []docsonnet.Result, formats []string, lint docsonnet.LintRules, renderOpts render.Opts) error {
+	failed := 0
+	written := make(map[string]string)
+	for i, r := range results {
+		t := targets[i]
+		if !loaded(r, lint) {
+			failed++
+			continue
+		}
+
+		if t.byName {
+			t.output = filepath.Join(t.output, r.Package.Name)
+			t.urlPrefix = path.Join(t.urlPrefix, r.Package.Name)
+		}
+		if other, ok := written[t.output]; ok {
+			log.Printf("%s: '%s' was already written from '%s'", r.File, t.output, other)
+			failed++
+			continue
+		}
+		written[t.output] = r.File
+
+		o := renderOpts
+		o.URLPrefix = t.urlPrefix
+		if err := write(*r.Package, t.output, formats, o); err != nil {
+			log.Printf("%s: %s", r.File, err)
+			failed++
+			continue
+		}
+		log.Printf("Wrote '%s' to '%s'", r.File, t.output)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d libraries failed", failed, len(results))
+	}
+
+	log.Printf("Success! Documented %d libraries", len(results))
+	return nil
+}
+
+// mergeResults writes the packages of `results` to `dir` as a single tree, below
+// a root package named `name`
+func mergeResults(results []docsonnet.Result, name, help, dir string, formats []string, lint docsonnet.LintRules, renderOpts render.Opts) error {
+	pkgs := make([]docsonnet.Package, 0, len(results))
+	for _, r := range results {
+		if loaded(r, lint) {
+			pkgs = append(pkgs, *r.Package)
+		}
+	}
+	if failed := len(results) - len(pkgs); failed > 0 {
+		return fmt.Errorf("%d of %d libraries failed", failed, len(results))
+	}
+
+	root, err := docsonnet.Merge(name, help, pkgs...)
+	if err != nil {
+		return err
+	}
+	if err := write(*root, dir, formats, renderOpts); err != nil {
+		return err
+	}
+
+	log.Printf("Success! Documented %d libraries as '%s' in '%s'", len(pkgs), name, dir)
+	return nil
+}
+
+// write `pkg` to `dir` in each of `formats`
+func write(pkg docsonnet.Package, dir string, formats []string, opts render.Opts) error {
+	for _, f := range formats {
+		switch f {
+		case formatMarkdown:
+			if _, err := render.To(pkg, dir, opts); err != nil {
+				return fmt.Errorf("rendering: %w", err)
+			}
+		case formatJSON:
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				return err
+			}
+			file, err := os.Create(filepath.Join(dir, modelFile))
+			if err != nil {
+				return err
+			}
+			err = docsonnet.Encode(file, pkg)
+			if cerr := file.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown format '%s', expected one of %s", f, strings.Join([]string{formatMarkdown, formatJSON}, ", "))
+		}
+	}
+	return nil
+}
+
+// logDocUtil reports the doc-util the library at `file` was loaded with, as
+// found by docsonnet.DetectDocUtil
+func logDocUtil(file string, du *docsonnet.DocUtil, err error) {
+	if err != nil {
+		log.Printf("%s: detecting doc-util: %s", file, err)
+	}
+	if du == nil {
+		return
+	}
+
+	log.Printf("%s: library uses doc-util %s", file, du)
+	if missing := du.Unsupported(); len(missing) > 0 {
+		log.Printf("%s: this doc-util does not support %s", file, strings.Join(missing, ", "))
+	}
+}
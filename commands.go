@@ -0,0 +1,408 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/go-clix/cli"
+	"github.com/posener/complete"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/render"
+)
+
+// argsLibraries accepts any number of libraries, which may also be configured
+// instead
+func argsLibraries() cli.Arguments {
+	return cli.Args{
+		Validator: cli.ValidateAny(),
+		Predictor: predictLibraries,
+	}
+}
+
+func renderCmd() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "render [<file>...]",
+		Short: "render markdown documentation of libraries",
+		Long: `Render markdown documentation of the given libraries, or the entrypoints of the configuration file.
+Multiple libraries are written to subdirectories of the output directory, named after their package.`,
+		Args: argsLibraries(),
+	}
+
+	load := addLoadFlags(cmd)
+	rf := addRenderFlags(cmd)
+
+	// superseded by the json and raw subcommands
+	outputJSON := cmd.Flags().Bool("json", false, "print loaded docsonnet as JSON")
+	outputRaw := cmd.Flags().Bool("raw", false, "don't transform, dump raw eval result")
+	_ = cmd.Flags().MarkDeprecated("json", "use `docsonnet json` instead")
+	_ = cmd.Flags().MarkDeprecated("raw", "use `docsonnet raw` instead")
+
+	from := cmd.Flags().String("from", "", "render the output of 'docsonnet raw' (raw) or 'docsonnet json' (model) read from the given files, or stdin ('-'), instead of libraries")
+	predict(cmd, "from", complete.PredictSet(fromRaw, fromModel))
+
+	merge := cmd.Flags().String("merge", "", "document all libraries as a single tree, below a root package of this name with a landing page listing them")
+	mergeHelp := cmd.Flags().String("mergeHelp", "", "description of the root package created by --merge")
+
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		switch *from {
+		case "", fromRaw, fromModel:
+		default:
+			return fmt.Errorf("unknown input format '%s', expected %s or %s", *from, fromRaw, fromModel)
+		}
+
+		// inputs aren't configured, read stdin unless given
+		if *from != "" && len(args) == 0 {
+			args = []string{stdin}
+		}
+
+		cfg, entries, opts, err := load.resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+		lint := docsonnet.LintRules(cfg.Lint)
+
+		if *outputJSON || *outputRaw {
+			file, err := single(entries)
+			if err != nil {
+				return err
+			}
+			return dump(file, *outputRaw, lint, opts)
+		}
+
+		output, formats, renderOpts, err := rf.resolve(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		targets, err := targets(entries, output, renderOpts.URLPrefix)
+		if err != nil {
+			return err
+		}
+
+		var results []docsonnet.Result
+		if *from != "" {
+			results = readInputs(targets, *from)
+		} else {
+			results = loadTargets(targets, *load.concurrency, opts)
+		}
+
+		set := cmd.Flags().Changed
+		if cfg.Merge.Name != "" && !set("merge") {
+			*merge = cfg.Merge.Name
+		}
+		if cfg.Merge.Help != "" && !set("mergeHelp") {
+			*mergeHelp = cfg.Merge.Help
+		}
+		if *merge != "" {
+			return mergeResults(results, *merge, *mergeHelp, output, formats, lint, renderOpts)
+		}
+		return writeResults(targets, results, formats, lint, renderOpts)
+	}
+	return cmd
+}
+
+func jsonCmd() *cli.Command {
+	return dumpCmd("json [<file>]", "print the docsonnet model of a library as JSON", false)
+}
+
+func rawCmd() *cli.Command {
+	return dumpCmd("raw [<file>]", "print the docstrings of a library as extracted from Jsonnet, without transforming them", true)
+}
+
+func dumpCmd(use, short string, raw bool) *cli.Command {
+	cmd := &cli.Command{
+		Use:   use,
+		Short: short,
+		Args:  argsLibraries(),
+	}
+
+	load := addLoadFlags(cmd)
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		cfg, entries, opts, err := load.resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+		file, err := single(entries)
+		if err != nil {
+			return err
+		}
+		return dump(file, raw, docsonnet.LintRules(cfg.Lint), opts)
+	}
+	return cmd
+}
+
+// dump prints the model of the library at `file` as JSON, or the raw extracted
+// data if `raw` is set
+func dump(file string, raw bool, lint docsonnet.LintRules, opts docsonnet.Opts) error {
+	var diags docsonnet.Diagnostics
+	opts.Diagnostics = &diags
+
+	// the bundled doc-util supports everything
+	if opts.DocUtil != "" && opts.DocUtil != docsonnet.DocUtilBundled {
+		du, err := docsonnet.DetectDocUtil(file, opts)
+		logDocUtil(file, du, err)
+	}
+
+	log.Println("Extracting from Jsonnet")
+	extract := docsonnet.Extract
+	if opts.Static {
+		extract = docsonnet.ExtractStatic
+	}
+	data, err := extract(file, opts)
+	if err != nil {
+		return fmt.Errorf("extracting: %w", err)
+	}
+	if raw {
+		fmt.Println(string(data))
+		return checkDiagnostics(lint.Apply(diags))
+	}
+
+	log.Println("Transforming to docsonnet model")
+	pkg, tdiags, err := docsonnet.TransformWithDiagnostics(data)
+	if err != nil {
+		return fmt.Errorf("transforming: %w", err)
+	}
+	if err := docsonnet.Encode(os.Stdout, *pkg); err != nil {
+		return err
+	}
+	return checkDiagnostics(lint.Apply(append(diags, tdiags...)))
+}
+
+func lintCmd() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "lint [<file>...]",
+		Short: "report problems in the documentation of libraries",
+		Long: `Report problems in the documentation of libraries, like unknown types or docstrings that don't match their field.
+Fails if any of them is an error. The severity of each rule can be configured in the lint section of the configuration file.`,
+		Args: argsLibraries(),
+	}
+
+	load := addLoadFlags(cmd)
+	strict := cmd.Flags().Bool("strict", false, "treat warnings as errors")
+
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		cfg, entries, opts, err := load.resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+		targets, err := targets(entries, "", "")
+		if err != nil {
+			return err
+		}
+
+		files := make([]string, len(targets))
+		for i, t := range targets {
+			files[i] = t.file
+		}
+
+		problems := 0
+		for _, r := range docsonnet.LoadAll(files, *load.concurrency, opts) {
+			if r.Err != nil {
+				fmt.Printf("%s: %s\n", r.File, r.Err)
+				problems++
+				continue
+			}
+
+			for _, d := range docsonnet.LintRules(cfg.Lint).Apply(r.Diagnostics) {
+				fmt.Printf("%s: %s\n", r.File, d)
+				if d.Severity == docsonnet.SeverityError || *strict {
+					problems++
+				}
+			}
+		}
+
+		if problems > 0 {
+			return fmt.Errorf("found %d problems", problems)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func coverageCmd() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "coverage [<file>...]",
+		Short: "report which fields of libraries are documented",
+		Long: `Report which fields of libraries are documented. Only fields of objects that have at least one docstring are
+considered, fields whose docstring is 'ignore' are left out.`,
+		Args: argsLibraries(),
+	}
+
+	load := addLoadFlags(cmd)
+	min := cmd.Flags().Float64("min", 0, "fail if less than this percentage of fields is documented")
+	quiet := cmd.Flags().BoolP("quiet", "q", false, "don't list the undocumented fields")
+
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		_, entries, opts, err := load.resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+		targets, err := targets(entries, "", "")
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, t := range targets {
+			c, err := docsonnet.MeasureCoverage(t.file, opts)
+			if err != nil {
+				return fmt.Errorf("%s: %w", t.file, err)
+			}
+
+			percent := c.Ratio() * 100
+			fmt.Printf("%s: %.1f%% of %d fields documented\n", t.file, percent, len(c.Documented)+len(c.Undocumented))
+			if !*quiet {
+				for _, f := range c.Undocumented {
+					fmt.Println("  " + f)
+				}
+			}
+
+			if percent < *min {
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d libraries have less than %.1f%% of their fields documented", failed, len(targets), *min)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func diffCmd() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "diff <old> <new>",
+		Short: "compare the API of two versions of a library",
+		Long: `Compare the API of two versions of a library, listing the fields that were added, removed or changed their signature.
+Either version can be a library or a model previously written using the json subcommand (*.json). Fails if there are changes.`,
+		Args: cli.Args{
+			Validator: cli.ValidateExact(2),
+			Predictor: predictLibraries,
+		},
+	}
+
+	load := addLoadFlags(cmd)
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		_, _, opts, err := load.resolve(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		old, err := loadPackage(args[0], opts)
+		if err != nil {
+			return err
+		}
+		new, err := loadPackage(args[1], opts)
+		if err != nil {
+			return err
+		}
+
+		changes := docsonnet.Diff(*old, *new)
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+		if len(changes) > 0 {
+			return fmt.Errorf("found %d changes", len(changes))
+		}
+		return nil
+	}
+	return cmd
+}
+
+// loadPackage loads the library at `file`, or reads it from JSON if it is a
+// *.json file
+func loadPackage(file string, opts docsonnet.Opts) (*docsonnet.Package, error) {
+	if filepath.Ext(file) != ".json" {
+		pkg, err := docsonnet.Load(file, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		return pkg, nil
+	}
+
+	pkg, _, err := readInput(file, fromModel)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return pkg, nil
+}
+
+func showCmd() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "show <file> [<path>]",
+		Short: "print the documentation of a library, or one of its fields",
+		Long:  `Print the documentation of a library, or of the field at the dotted path, e.g. 'dashboard.new'.`,
+		Args: cli.Args{
+			Validator: cli.ValidateFunc(func(args []string) error {
+				if len(args) < 1 || len(args) > 2 {
+					return fmt.Errorf("expected a file and optionally the path of a field, got %d arguments", len(args))
+				}
+				return nil
+			}),
+			Predictor: predictLibraries,
+		},
+	}
+
+	load := addLoadFlags(cmd)
+	rf := addRenderFlags(cmd)
+	cmd.Run = func(cmd *cli.Command, args []string) error {
+		cfg, _, opts, err := load.resolve(cmd, args[:1])
+		if err != nil {
+			return err
+		}
+		_, _, renderOpts, err := rf.resolve(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		pkg, err := loadPackage(args[0], opts)
+		if err != nil {
+			return err
+		}
+
+		// resolve references within the whole library, not only the part shown
+		resolved, err := render.ResolveRefs(*pkg, renderOpts)
+		if err != nil {
+			return err
+		}
+
+		path := ""
+		if len(args) > 1 {
+			path = args[1]
+		}
+		field, sub := resolved.Lookup(path)
+		switch {
+		case field != nil:
+			section, err := render.Field(path, *field, renderOpts)
+			if err != nil {
+				return err
+			}
+			fmt.Println(section)
+		case sub != nil:
+			pages, err := render.Render(*sub, renderOpts)
+			if err != nil {
+				return err
+			}
+			fmt.Println(pages["README.md"])
+		default:
+			return fmt.Errorf("%s has no field '%s'", pkg.Name, path)
+		}
+		return nil
+	}
+	return cmd
+}
+
+// checkDiagnostics logs `diags`, failing if any of them is an error
+func checkDiagnostics(diags docsonnet.Diagnostics) error {
+	for _, d := range diags {
+		log.Println(d)
+	}
+	if n := diags.Errors(); n > 0 {
+		return fmt.Errorf("%d diagnostics are errors", n)
+	}
+	return nil
+}
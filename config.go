@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-jsonnet"
+	"sigs.k8s.io/yaml"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// configFiles are the names of the configuration file, looked up in the
+// working directory and its parents
+var configFiles = []string{"docsonnet.yaml", "docsonnet.yml", "docsonnet.jsonnet"}
+
+// config is the project configuration file. Relative paths in it are relative
+// to the directory of the file.
+type config struct {
+	// Entrypoints are the libraries to document
+	Entrypoints []entrypoint `json:"entrypoints"`
+
+	JPath          []string          `json:"jpath"`
+	ExtVars        map[string]string `json:"extVars"`
+	ExtCode        map[string]string `json:"extCode"`
+	TLAVars        map[string]string `json:"tlaVars"`
+	TLACode        map[string]string `json:"tlaCode"`
+	JsonnetBundler *bool             `json:"jsonnetBundler"`
+	DocUtil        string            `json:"docUtil"`
+	Static         *bool             `json:"static"`
+	Concurrency    int               `json:"concurrency"`
+
+	// Output is the directory to write to
+	Output string `json:"output"`
+	// Formats to write, `markdown` and/or `json`
+	Formats   []string `json:"formats"`
+	URLPrefix string   `json:"urlPrefix"`
+	// SourceLink is a template for links to the source of fields, see
+	// render.SourceLinkTemplate
+	SourceLink string `json:"sourceLink"`
+	// Slug is the strategy to create anchors with, see slug.ParseStrategy
+	Slug string `json:"slug"`
+	// Anchors is the style of explicit anchors, see render.ParseAnchorStyle
+	Anchors string `json:"anchors"`
+	// Order is the order fields are listed in, see render.ParseFieldOrder
+	Order string `json:"order"`
+	// SinglePage renders all packages onto one page, except those having
+	// more fields than SplitThreshold
+	SinglePage     *bool `json:"singlePage"`
+	SplitThreshold *int  `json:"splitThreshold"`
+	// Nav is the site generator to write navigation files for, see
+	// render.ParseNavStyle
+	Nav string `json:"nav"`
+	// Templates are files redefining the templates the pages are rendered
+	// with, see render.ParseTemplates
+	Templates []string `json:"templates"`
+	// Frontmatter configures the frontmatter of the pages, see
+	// render.FrontmatterOpts
+	Frontmatter struct {
+		Disable *bool             `json:"disable"`
+		Fields  map[string]string `json:"fields"`
+	} `json:"frontmatter"`
+	// Merge documents all entrypoints as a single tree
+	Merge struct {
+		// Name of the root package
+		Name string `json:"name"`
+		// Help is the description of the root package, shown on the
+		// landing page
+		Help string `json:"help"`
+	} `json:"merge"`
+	Defaults struct {
+		MaxLength   *int `json:"maxLength"`
+		BlockLength *int `json:"blockLength"`
+	} `json:"defaults"`
+
+	// Lint configures the severity of diagnostics
+	Lint lintRules `json:"lint"`
+}
+
+// lintRules are docsonnet.LintRules, that also accept `false` for "off", as
+// YAML turns an unquoted `off` into that
+type lintRules docsonnet.LintRules
+
+func (l *lintRules) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*l = make(lintRules, len(raw))
+	for rule, v := range raw {
+		switch sev := v.(type) {
+		case string:
+			(*l)[rule] = sev
+		case bool:
+			if sev {
+				return fmt.Errorf("lint rule '%s': severity must be one of off, warning, error. Got 'true'", rule)
+			}
+			(*l)[rule] = docsonnet.RuleOff
+		default:
+			return fmt.Errorf("lint rule '%s': severity must be a string", rule)
+		}
+	}
+	return nil
+}
+
+// entrypoint is a library to document. `file` may be a glob pattern.
+type entrypoint struct {
+	File string `json:"file"`
+	// Output is the directory below config.Output to write this library to
+	Output string `json:"output"`
+	// URLPrefix overrides config.URLPrefix for this library
+	URLPrefix string `json:"urlPrefix"`
+}
+
+// UnmarshalJSON also accepts just the file name
+func (e *entrypoint) UnmarshalJSON(data []byte) error {
+	var file string
+	if err := json.Unmarshal(data, &file); err == nil {
+		*e = entrypoint{File: file}
+		return nil
+	}
+
+	type plain entrypoint
+	return strictUnmarshal(data, (*plain)(e))
+}
+
+// findConfig looks for a configuration file in `dir` and its parents. Returns
+// an empty string if there is none.
+func findConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range configFiles {
+			file := filepath.Join(dir, name)
+			if _, err := os.Stat(file); err == nil {
+				return file, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// loadConfig reads the configuration file at `file`, which is either YAML or
+// Jsonnet, depending on its extension
+func loadConfig(file string) (*config, error) {
+	var data []byte
+	var err error
+	if filepath.Ext(file) == ".jsonnet" {
+		var out string
+		out, err = jsonnet.MakeVM().EvaluateFile(file)
+		data = []byte(out)
+	} else {
+		data, err = os.ReadFile(file)
+		if err == nil {
+			data, err = yaml.YAMLToJSON(data)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", file, err)
+	}
+
+	var cfg config
+	if err := strictUnmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	if err := docsonnet.LintRules(cfg.Lint).Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	cfg.resolvePaths(filepath.Dir(file))
+	return &cfg, nil
+}
+
+// resolvePaths makes the paths of c relative to the working directory instead
+// of `dir`
+func (c *config) resolvePaths(dir string) {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, dir); err == nil {
+			dir = rel
+		}
+	}
+
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+
+	for i := range c.Entrypoints {
+		c.Entrypoints[i].File = resolve(c.Entrypoints[i].File)
+	}
+	for i := range c.JPath {
+		c.JPath[i] = resolve(c.JPath[i])
+	}
+	for i := range c.Templates {
+		c.Templates[i] = resolve(c.Templates[i])
+	}
+	c.Output = resolve(c.Output)
+	if c.DocUtil != docsonnet.DocUtilBundled && c.DocUtil != docsonnet.DocUtilVendored {
+		c.DocUtil = resolve(c.DocUtil)
+	}
+}
+
+func strictUnmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
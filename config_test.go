@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-clix/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// abs makes the path `p`, which is relative to the working directory,
+// absolute
+func abs(t *testing.T, p string) string {
+	t.Helper()
+	a, err := filepath.Abs(p)
+	require.NoError(t, err)
+	return a
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := writeFile(t, filepath.Join(dir, "yaml", "docsonnet.yaml"), `
+entrypoints:
+  - main.libsonnet
+  - file: libs/*.libsonnet
+    output: libs
+jpath: [vendor]
+templates: [docs.tmpl]
+output: docs
+docUtil: vendored
+lint:
+  unknown-type: error
+  static-eval: off
+`)
+
+	jsonnetFile := writeFile(t, filepath.Join(dir, "jsonnet", "docsonnet.jsonnet"), `{
+  entrypoints: ['main.libsonnet', { file: 'libs/*.libsonnet', output: 'libs' }],
+  jpath: ['vendor'],
+  templates: ['docs.tmpl'],
+  output: 'docs',
+  docUtil: 'vendored',
+  lint: { 'unknown-type': 'error', 'static-eval': false },
+}`)
+
+	for _, file := range []string{yamlFile, jsonnetFile} {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			cfg, err := loadConfig(file)
+			require.NoError(t, err)
+
+			// paths are relative to the configuration file
+			dir := filepath.Dir(file)
+			require.Len(t, cfg.Entrypoints, 2)
+			assert.Equal(t, filepath.Join(dir, "main.libsonnet"), abs(t, cfg.Entrypoints[0].File))
+			assert.Equal(t, filepath.Join(dir, "libs/*.libsonnet"), abs(t, cfg.Entrypoints[1].File))
+			assert.Equal(t, "libs", cfg.Entrypoints[1].Output)
+			assert.Equal(t, filepath.Join(dir, "vendor"), abs(t, cfg.JPath[0]))
+			assert.Equal(t, filepath.Join(dir, "docs.tmpl"), abs(t, cfg.Templates[0]))
+			assert.Equal(t, filepath.Join(dir, "docs"), abs(t, cfg.Output))
+			assert.Equal(t, docsonnet.DocUtilVendored, cfg.DocUtil)
+
+			// `off` is `false` in YAML
+			assert.Equal(t, lintRules{
+				docsonnet.RuleUnknownType: "error",
+				docsonnet.RuleStaticEval:  docsonnet.RuleOff,
+			}, cfg.Lint)
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"unknown field":    "entrypoint: main.libsonnet",
+		"true severity":    "lint: {unknown-type: true}",
+		"unknown severity": "lint: {unknown-type: fatal}",
+		"unknown rule":     "lint: {typo: error}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := writeFile(t, filepath.Join(dir, name, "docsonnet.yaml"), content)
+			_, err := loadConfig(file)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, filepath.Join(dir, "docsonnet.yml"), "")
+	nested := filepath.Join(dir, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	got, err := findConfig(nested)
+	require.NoError(t, err)
+	assert.Equal(t, file, got)
+}
+
+func TestFlagsOverrideConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, filepath.Join(dir, "docsonnet.yaml"), `
+entrypoints: [main.libsonnet]
+static: true
+docUtil: vendored
+concurrency: 4
+output: docs
+order: explicit
+`)
+
+	cmd := &cli.Command{Use: "render"}
+	load := addLoadFlags(cmd)
+	render := addRenderFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--config", file, "--static=false", "--order", "alphabetical"}))
+
+	cfg, entries, opts, err := load.resolve(cmd, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(dir, "main.libsonnet"), abs(t, entries[0].File))
+
+	// set on the command line
+	assert.False(t, opts.Static)
+	// only set in the configuration
+	assert.Equal(t, docsonnet.DocUtilVendored, opts.DocUtil)
+	assert.Equal(t, 4, *load.concurrency)
+
+	output, _, ropts, err := render.resolve(cmd, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "docs"), abs(t, output))
+	assert.Equal(t, []string{"a", "b"}, ropts.Order(docsonnet.Fields{
+		"b": {Function: &docsonnet.Function{Name: "b"}},
+		"a": {Value: &docsonnet.Value{Name: "a"}},
+	}))
+
+	// arguments replace the entrypoints
+	_, entries, _, err = load.resolve(cmd, []string{"other.libsonnet"})
+	require.NoError(t, err)
+	assert.Equal(t, []entrypoint{{File: "other.libsonnet"}}, entries)
+}
@@ -141,6 +141,14 @@
     withArgs(args):: { 'function'+: {
       args: args,
     } },
+
+    '#withReturn': d.fn('The `withReturn` modifier documents the type of the value returned by that function, optionally with a description', [d.arg('type', d.T.string), d.arg('help', d.T.string)]),
+    withReturn(type, help=''):: { 'function'+: {
+      'return': {
+        type: type,
+        help: help,
+      },
+    } },
   },
 
   '#fn': self.func['#new'] + d.func.withHelp('`fn` is a shorthand for `func.new`'),
@@ -220,7 +228,7 @@
     integer: self.number,
 
     '#boolean': d.val(d.T.string, 'argument of type "boolean"'),
-    boolean: 'bool',
+    boolean: 'boolean',
     bool: self.boolean,
 
     '#object': d.val(d.T.string, 'argument of type "object"'),
@@ -236,11 +244,34 @@
     'null': 'null',
     nil: self['null'],
 
-    '#func': d.val(d.T.string, 'argument of type "func"'),
+    '#func': d.val(d.T.string, 'argument of type "function"'),
     func: 'function',
     'function': self.func,
+
+    '#union': d.fn('`union` describes an argument that accepts any of the given `types`, e.g. `d.T.union([d.T.string, d.T.array])`', [d.arg('types', d.T.array)]),
+    union(types):: std.join(' | ', types),
+
+    '#arrayOf': d.fn('`arrayOf` describes an array holding elements of the given `type`, e.g. `d.T.arrayOf(d.T.string)`', [d.arg('type', d.T.string)]),
+    arrayOf(type):: 'array<%s>' % type,
+
+    '#objectOf': d.fn('`objectOf` describes an object with fields of the given `type`, e.g. `d.T.objectOf(d.T.number)`', [d.arg('type', d.T.string)]),
+    objectOf(type):: 'object<%s>' % type,
   },
 
+  '#group': d.fn(|||
+    `group` puts the documented field into a named group, like "Constructors" or "Modifiers". Add it to the
+    docstring, e.g. `d.fn('...') + d.group('Modifiers')`. Each group is rendered as a subsection of the fields and
+    of the index, listing its fields together.
+  |||, [d.arg('name', d.T.string)]),
+  group(name):: { group: name },
+
+  '#order': d.fn(|||
+    `order` sets the position of the documented field among the others of its object or group, when rendering
+    with the `explicit` field order. Add it to the docstring, e.g. `d.fn('...') + d.order(1)`. Fields without a
+    position follow those having one.
+  |||, [d.arg('position', d.T.number)]),
+  order(position):: { order: position },
+
   '#render': d.fn(
     |||
       `render` converts the docstrings to human readable Markdown files.
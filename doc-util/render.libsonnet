@@ -361,15 +361,21 @@
         ])
       else '',
 
+    returns:
+      local ret = std.get(doc['function'], 'return', null);
+      if ret != null
+      then ': ' + ret.type
+      else '',
+
     toString():
       std.join('\n', [
         root.util.title('fn ' + self.path, std.length(path) + 2),
         |||
           ```jsonnet
-          %s(%s)
+          %s(%s)%s
           ```
           %s
-        ||| % [self.path, self.args, self.args_list],
+        ||| % [self.path, self.args, self.returns, self.args_list],
         std.get(doc['function'], 'help', ''),
       ]),
   },
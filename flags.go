@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-clix/cli"
+	"github.com/posener/complete"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/render"
+	"github.com/jsonnet-libs/docsonnet/pkg/slug"
+)
+
+// predictLibraries completes Jsonnet files
+var predictLibraries = complete.PredictFiles("*sonnet")
+
+func predict(cmd *cli.Command, flag string, p complete.Predictor) {
+	if cmd.Predictors == nil {
+		cmd.Predictors = make(map[string]complete.Predictor)
+	}
+	cmd.Predictors[flag] = p
+}
+
+// loadFlags control how libraries are loaded
+type loadFlags struct {
+	config      *string
+	jpath       *[]string
+	jb          *bool
+	docUtil     *string
+	static      *bool
+	vars        *jsonnetVars
+	concurrency *int
+}
+
+func addLoadFlags(cmd *cli.Command) *loadFlags {
+	fs := cmd.Flags()
+	l := &loadFlags{
+		config:      fs.String("config", "", "configuration file to use. Defaults to docsonnet.yaml, docsonnet.yml or docsonnet.jsonnet in the working directory or its parents"),
+		jpath:       fs.StringSliceP("jpath", "J", []string{"vendor"}, "Specify an additional library search dir (right-most wins)"),
+		jb:          fs.Bool("jb", true, "resolve imports using the jsonnetfile.json of the library and its vendor directory"),
+		docUtil:     fs.String("docUtil", docsonnet.DocUtilBundled, "doc-util to use: 'bundled', 'vendored' (the one of the library, if any) or a path"),
+		static:      fs.Bool("static", false, "collect docstrings from the source instead of evaluating the library"),
+		vars:        addJsonnetVarFlags(fs),
+		concurrency: fs.Int("concurrency", 0, "number of libraries to load in parallel when given multiple files (0 uses the number of CPUs)"),
+	}
+
+	predict(cmd, "config", complete.PredictOr(complete.PredictFiles("*.yaml"), complete.PredictFiles("*.yml"), complete.PredictFiles("*.jsonnet")))
+	predict(cmd, "jpath", complete.PredictDirs("*"))
+	predict(cmd, "docUtil", complete.PredictOr(complete.PredictSet(docsonnet.DocUtilBundled, docsonnet.DocUtilVendored), complete.PredictDirs("*")))
+	for _, name := range []string{"ext-str-file", "ext-code-file", "tla-str-file", "tla-code-file"} {
+		predict(cmd, name, complete.PredictFiles("*"))
+	}
+	return l
+}
+
+// resolve reads the configuration file and returns it along with the
+// entrypoints to document and the options to load them with. Flags given on
+// the command line take precedence over the configuration.
+func (l *loadFlags) resolve(cmd *cli.Command, args []string) (*config, []entrypoint, docsonnet.Opts, error) {
+	cfg := &config{}
+	file := *l.config
+	if file == "" {
+		found, err := findConfig(".")
+		if err != nil {
+			return nil, nil, docsonnet.Opts{}, err
+		}
+		file = found
+	}
+	if file != "" {
+		var err error
+		if cfg, err = loadConfig(file); err != nil {
+			return nil, nil, docsonnet.Opts{}, err
+		}
+		log.Printf("Using configuration from '%s'", file)
+	}
+
+	entries := cfg.Entrypoints
+	if len(args) > 0 {
+		entries = make([]entrypoint, len(args))
+		for i, a := range args {
+			entries[i] = entrypoint{File: a}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil, docsonnet.Opts{}, fmt.Errorf("no files given, and no entrypoints configured")
+	}
+
+	set := cmd.Flags().Changed
+	opts := docsonnet.Opts{
+		JPath:          *l.jpath,
+		JsonnetBundler: *l.jb,
+		DocUtil:        *l.docUtil,
+		Static:         *l.static,
+		ExtVars:        cfg.ExtVars,
+		ExtCode:        cfg.ExtCode,
+		TLAVars:        cfg.TLAVars,
+		TLACode:        cfg.TLACode,
+	}
+	if cfg.JPath != nil && !set("jpath") {
+		opts.JPath = cfg.JPath
+	}
+	if cfg.JsonnetBundler != nil && !set("jb") {
+		opts.JsonnetBundler = *cfg.JsonnetBundler
+	}
+	if cfg.DocUtil != "" && !set("docUtil") {
+		opts.DocUtil = cfg.DocUtil
+	}
+	if cfg.Static != nil && !set("static") {
+		opts.Static = *cfg.Static
+	}
+	if cfg.Concurrency != 0 && !set("concurrency") {
+		*l.concurrency = cfg.Concurrency
+	}
+	if err := l.vars.mergeInto(&opts); err != nil {
+		return nil, nil, docsonnet.Opts{}, err
+	}
+
+	return cfg, entries, opts, nil
+}
+
+// single returns the only library of `entries`
+func single(entries []entrypoint) (string, error) {
+	targets, err := targets(entries, "", "")
+	if err != nil {
+		return "", err
+	}
+	if len(targets) != 1 {
+		return "", fmt.Errorf("expected a single library, got %d", len(targets))
+	}
+	return targets[0].file, nil
+}
+
+// renderFlags control the generated markdown
+type renderFlags struct {
+	output      *string
+	formats     *[]string
+	urlPrefix   *string
+	sourceLink  *string
+	maxLength   *int
+	blockLength *int
+	slug        *string
+	anchors     *string
+	nav         *string
+	order       *string
+	singlePage  *bool
+	split       *int
+	templates   *[]string
+	frontmatter *map[string]string
+	noFront     *bool
+}
+
+func addRenderFlags(cmd *cli.Command) *renderFlags {
+	fs := cmd.Flags()
+	r := &renderFlags{
+		output:      fs.StringP("output", "o", "docs", "directory to write the .md files to"),
+		formats:     fs.StringSlice("format", []string{formatMarkdown}, "formats to write to the output directory: markdown, json"),
+		urlPrefix:   fs.String("urlPrefix", "/", "url-prefix for frontmatter"),
+		sourceLink:  fs.String("sourceLink", "", "template for links to the source of fields, e.g. 'https://github.com/org/repo/blob/main/{{.File}}#L{{.Line}}'"),
+		maxLength:   fs.Int("defaultMaxLength", 0, "truncate defaults in function signatures longer than this (0 disables)"),
+		blockLength: fs.Int("defaultBlockLength", 60, "render defaults of values at least this long as a code block (0 disables)"),
+		anchors:     fs.String("anchors", "none", "add explicit anchors to the headlines of fields, which don't change with their text: none, html (<a id=\"...\">) or attribute ({#...})"),
+		slug:        fs.String("slug", slug.GitHub.String(), "create anchors of headlines like the markdown engine the docs are published with: "+strings.Join(slug.Strategies(), ", ")),
+		nav:         fs.String("nav", "none", "write the navigation files of a site generator next to the pages: none, mkdocs (mkdocs.yml), docusaurus (sidebars.js and _category_.json) or hugo (_index.md)"),
+		order:       fs.String("order", "default", "order to list fields in: default (constructors, functions, others), alphabetical, kind, source (requires --static) or explicit (using d.group and d.order)"),
+		singlePage:  fs.Bool("singlePage", false, "render sub packages into the page of their parent, with a table of contents of all packages"),
+		split:       fs.Int("splitThreshold", 0, "keep sub packages with more than this many fields on their own page when using --singlePage (0 disables)"),
+		frontmatter: fs.StringToString("frontmatter", nil, "add fields to the frontmatter of pages, e.g. 'title={{ .Title }},weight={{ .Weight }}'. Values are text/templates"),
+		noFront:     fs.Bool("noFrontmatter", false, "leave out the frontmatter, e.g. for docs rendered by GitHub"),
+		templates:   fs.StringSlice("templates", nil, "text/template files redefining the templates of pages (package, index, function, object, value, category)"),
+	}
+
+	predict(cmd, "output", complete.PredictDirs("*"))
+	predict(cmd, "format", complete.PredictSet(formatMarkdown, formatJSON))
+	predict(cmd, "slug", complete.PredictSet(slug.Strategies()...))
+	predict(cmd, "order", complete.PredictSet(render.FieldOrders()...))
+	predict(cmd, "nav", complete.PredictSet("none", string(render.NavMkDocs), string(render.NavDocusaurus), string(render.NavHugo)))
+	predict(cmd, "templates", complete.PredictFiles("*"))
+	predict(cmd, "anchors", complete.PredictSet("none", string(render.AnchorsHTML), string(render.AnchorsAttribute)))
+	return r
+}
+
+// resolve returns the output directory, formats and render options, with flags
+// given on the command line taking precedence over `cfg`
+func (r *renderFlags) resolve(cmd *cli.Command, cfg *config) (string, []string, render.Opts, error) {
+	set := cmd.Flags().Changed
+
+	output, formats, link, strategy, anchors, nav, order, templates := *r.output, *r.formats, *r.sourceLink, *r.slug, *r.anchors, *r.nav, *r.order, *r.templates
+	if cfg.Output != "" && !set("output") {
+		output = cfg.Output
+	}
+	if cfg.Formats != nil && !set("format") {
+		formats = cfg.Formats
+	}
+	if cfg.SourceLink != "" && !set("sourceLink") {
+		link = cfg.SourceLink
+	}
+	if cfg.Slug != "" && !set("slug") {
+		strategy = cfg.Slug
+	}
+	if cfg.Anchors != "" && !set("anchors") {
+		anchors = cfg.Anchors
+	}
+	if cfg.Nav != "" && !set("nav") {
+		nav = cfg.Nav
+	}
+	if cfg.Order != "" && !set("order") {
+		order = cfg.Order
+	}
+	if cfg.Templates != nil && !set("templates") {
+		templates = cfg.Templates
+	}
+
+	opts := render.Opts{
+		URLPrefix: *r.urlPrefix,
+		Defaults: render.DefaultOpts{
+			MaxLength:   *r.maxLength,
+			BlockLength: *r.blockLength,
+		},
+		SinglePage:     *r.singlePage,
+		SplitThreshold: *r.split,
+		Frontmatter: render.FrontmatterOpts{
+			Disable: *r.noFront,
+			Fields:  *r.frontmatter,
+		},
+	}
+	if cfg.URLPrefix != "" && !set("urlPrefix") {
+		opts.URLPrefix = cfg.URLPrefix
+	}
+	if cfg.SinglePage != nil && !set("singlePage") {
+		opts.SinglePage = *cfg.SinglePage
+	}
+	if cfg.SplitThreshold != nil && !set("splitThreshold") {
+		opts.SplitThreshold = *cfg.SplitThreshold
+	}
+	if cfg.Frontmatter.Fields != nil && !set("frontmatter") {
+		opts.Frontmatter.Fields = cfg.Frontmatter.Fields
+	}
+	if cfg.Frontmatter.Disable != nil && !set("noFrontmatter") {
+		opts.Frontmatter.Disable = *cfg.Frontmatter.Disable
+	}
+	if cfg.Defaults.MaxLength != nil && !set("defaultMaxLength") {
+		opts.Defaults.MaxLength = *cfg.Defaults.MaxLength
+	}
+	if cfg.Defaults.BlockLength != nil && !set("defaultBlockLength") {
+		opts.Defaults.BlockLength = *cfg.Defaults.BlockLength
+	}
+	var err error
+	if opts.Slug, err = slug.ParseStrategy(strategy); err != nil {
+		return "", nil, render.Opts{}, err
+	}
+	if opts.Anchors, err = render.ParseAnchorStyle(anchors); err != nil {
+		return "", nil, render.Opts{}, err
+	}
+	if opts.Nav, err = render.ParseNavStyle(nav); err != nil {
+		return "", nil, render.Opts{}, err
+	}
+	if opts.Order, err = render.ParseFieldOrder(order); err != nil {
+		return "", nil, render.Opts{}, err
+	}
+	if link != "" {
+		if opts.SourceLink, err = render.SourceLinkTemplate(link); err != nil {
+			return "", nil, render.Opts{}, fmt.Errorf("parsing source link template: %w", err)
+		}
+	}
+	if len(templates) > 0 {
+		if opts.Templates, err = render.ParseTemplates(templates...); err != nil {
+			return "", nil, render.Opts{}, fmt.Errorf("parsing templates: %w", err)
+		}
+	}
+
+	return output, formats, opts, nil
+}
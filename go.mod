@@ -7,8 +7,12 @@ require (
 	github.com/google/go-cmp v0.4.0
 	github.com/google/go-jsonnet v0.18.0
 	github.com/markbates/pkger v0.15.1
+	github.com/posener/complete v1.2.3
+	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.4.0
+	golang.org/x/text v0.3.8
 	gopkg.in/yaml.v2 v2.2.7
+	sigs.k8s.io/yaml v1.1.0
 )
 
 require (
@@ -17,7 +21,4 @@ require (
 	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/hashicorp/go-multierror v1.0.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/posener/complete v1.2.3 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
-	sigs.k8s.io/yaml v1.1.0 // indirect
 )
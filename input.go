@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// Formats `render --from` accepts
+const (
+	// fromRaw is the output of `docsonnet raw`
+	fromRaw = "raw"
+	// fromModel is the output of `docsonnet json`
+	fromModel = "model"
+)
+
+// stdin is the file name that refers to the standard input
+const stdin = "-"
+
+// readInput reads a package from `file`, holding data in the `from` format
+func readInput(file, from string) (*docsonnet.Package, docsonnet.Diagnostics, error) {
+	var data []byte
+	var err error
+	if file == stdin {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch from {
+	case fromRaw:
+		pkg, diags, err := docsonnet.TransformWithDiagnostics(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("transforming: %w", err)
+		}
+		return pkg, diags, nil
+	case fromModel:
+		pkg, err := docsonnet.Decode(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing model: %w", err)
+		}
+		return pkg, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown input format '%s', expected %s or %s", from, fromRaw, fromModel)
+	}
+}
+
+// readInputs reads the packages of `targets` like LoadAll loads libraries
+func readInputs(targets []target, from string) []docsonnet.Result {
+	results := make([]docsonnet.Result, len(targets))
+	for i, t := range targets {
+		pkg, diags, err := readInput(t.file, from)
+		results[i] = docsonnet.Result{
+			File:        t.file,
+			Package:     pkg,
+			Diagnostics: diags,
+			Err:         err,
+		}
+	}
+	return results
+}
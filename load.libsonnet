@@ -8,6 +8,17 @@ local lib = {
       else old;
     std.foldl(aux, std.objectFieldsAll(obj), false),
 
+  // runtime describes the field `name` of `obj` as found during evaluation,
+  // to be attached to its docstring
+  runtime(obj, name)::
+    if name == '' || !std.objectHasAll(obj, name) then {}
+    else {
+      runtime: {
+        visibility: if std.objectHas(obj, name) then 'visible' else 'hidden',
+        type: std.type(obj[name]),
+      },
+    },
+
   load(pkg)::
     local aux(old, key) =
       if !std.isObject(pkg[key]) then
@@ -15,7 +26,7 @@ local lib = {
       else if std.objectHasAll(pkg, '#' + key) && pkg['#' + key] == 'ignore' then
         old
       else if std.startsWith(key, '#') then
-        old { [key]: pkg[key] }
+        old { [key]: pkg[key] + $.runtime(pkg, key[1:]) }
       else if self.scan(pkg[key]) then
         old { [key]: $.load(pkg[key]) }
       else old;
@@ -24,4 +35,4 @@ local lib = {
 };
 
 
-lib.load(std.extVar('main'))
+lib.load(std.extVar('__docsonnet_main'))
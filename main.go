@@ -1,71 +1,93 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"errors"
 	"log"
+	"os"
 
 	"github.com/go-clix/cli"
-
-	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
-	"github.com/jsonnet-libs/docsonnet/pkg/render"
 )
 
 func main() {
 	log.SetFlags(0)
 
 	root := &cli.Command{
-		Use:   "docsonnet <file>",
+		Use:   "docsonnet",
 		Short: "Utility to parse and transform Jsonnet code that uses the docsonnet extension",
-		Args:  cli.ArgsExact(1),
 	}
 
-	dir := root.Flags().StringP("output", "o", "docs", "directory to write the .md files to")
-	outputJSON := root.Flags().Bool("json", false, "print loaded docsonnet as JSON")
-	outputRaw := root.Flags().Bool("raw", false, "don't transform, dump raw eval result")
-	urlPrefix := root.Flags().String("urlPrefix", "/", "url-prefix for frontmatter")
-	jpath := root.Flags().StringSliceP("jpath", "J", []string{"vendor"}, "Specify an additional library search dir (right-most wins)")
+	commands := []*cli.Command{
+		renderCmd(),
+		jsonCmd(),
+		rawCmd(),
+		lintCmd(),
+		coverageCmd(),
+		diffCmd(),
+		showCmd(),
+	}
+	root.AddCommand(commands...)
 
-	root.Run = func(cmd *cli.Command, args []string) error {
-		file := args[0]
+	// `docsonnet <file>...` used to be the only command, keep it working
+	args := aliasRender(commands, os.Args[1:])
+	os.Args = append(os.Args[:1], args...)
 
-		log.Println("Extracting from Jsonnet")
-		data, err := docsonnet.Extract(file, docsonnet.Opts{JPath: *jpath})
-		if err != nil {
-			log.Fatalln("Extracting:", err)
-		}
-		if *outputRaw {
-			fmt.Println(string(data))
-			return nil
-		}
+	os.Exit(run(root, args))
+}
 
-		log.Println("Transforming to docsonnet model")
-		pkg, err := docsonnet.Transform(data)
-		if err != nil {
-			log.Fatalln("Transforming:", err)
+// aliasRender prefixes `args` with the render subcommand, unless they already
+// start with a subcommand or ask for help
+func aliasRender(commands []*cli.Command, args []string) []string {
+	if len(args) > 0 {
+		switch args[0] {
+		case "-h", "--help", "complete":
+			return args
 		}
-		if *outputJSON {
-			data, err := json.MarshalIndent(pkg, "", "  ")
-			if err != nil {
-				return err
+		for _, c := range commands {
+			if c.Name() == args[0] {
+				return args
 			}
-			fmt.Println(string(data))
-			return nil
 		}
+	}
+	return append([]string{"render"}, args...)
+}
 
-		log.Println("Rendering markdown")
-		n, err := render.To(*pkg, *dir, render.Opts{
-			URLPrefix: *urlPrefix,
-		})
-		if err != nil {
-			log.Fatalln("Rendering:", err)
-		}
+// Exit codes
+const (
+	exitOK = iota
+	// exitFailure is used if the command ran, but failed
+	exitFailure
+	// exitUsage is used if the command was invoked incorrectly
+	exitUsage
+)
 
-		log.Printf("Success! Rendered %v packages from '%s' to '%s'", n, file, *dir)
-		return nil
+func run(root *cli.Command, args []string) int {
+	err := root.Execute()
+	var usage cli.ErrHelp
+	switch {
+	case err == nil:
+		return exitOK
+	case helpRequested(args):
+		// the help is returned as an error
+		log.Println(err)
+		return exitOK
+	case errors.As(err, &usage):
+		log.Println(err)
+		return exitUsage
+	default:
+		log.Println(err)
+		return exitFailure
 	}
+}
 
-	if err := root.Execute(); err != nil {
-		log.Fatalln(err)
+// helpRequested reports whether the help flag is part of `args`
+func helpRequested(args []string) bool {
+	for _, a := range args {
+		switch a {
+		case "--":
+			return false
+		case "-h", "--help":
+			return true
+		}
 	}
+	return false
 }
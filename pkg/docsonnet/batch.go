@@ -0,0 +1,67 @@
+package docsonnet
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Result of loading one library using LoadAll
+type Result struct {
+	File        string
+	Package     *Package
+	Diagnostics Diagnostics
+	Err         error
+
+	// DocUtil is the doc-util the library was loaded with, if opts.DocUtil
+	// selects a vendored one or a path, see DetectDocUtil. DocUtilErr is set
+	// if detecting it failed.
+	DocUtil    *DocUtil
+	DocUtilErr error
+}
+
+// LoadAll loads the libraries at `filenames`, using up to `concurrency`
+// goroutines (GOMAXPROCS if zero). The libraries share opts.ImportCache and
+// each goroutine reuses its Jsonnet VM, so files imported by many of them, e.g.
+// from a common vendor tree, are only read once and parsed once per goroutine.
+// Results are in the order of `filenames`. opts.Diagnostics is unused, the
+// diagnostics of each library are part of its Result instead.
+func LoadAll(filenames []string, concurrency int, opts Opts) []Result {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	if opts.ImportCache == nil {
+		opts.ImportCache = NewImportCache()
+	}
+
+	results := make([]Result, len(filenames))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vm := newSharedVM()
+			for i := range jobs {
+				r := &results[i]
+				r.File = filenames[i]
+
+				o := opts
+				o.Diagnostics = &r.Diagnostics
+				o.vm = vm
+				if ownDocUtil(o) {
+					r.DocUtil, r.DocUtilErr = DetectDocUtil(r.File, o)
+				}
+				r.Package, r.Err = Load(r.File, o)
+			}
+		}()
+	}
+
+	for i := range filenames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
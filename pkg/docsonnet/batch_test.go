@@ -0,0 +1,66 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAll(t *testing.T) {
+	files := []string{
+		"testdata/bundler/main.libsonnet",
+		"testdata/missing.libsonnet",
+		"testdata/docutil/main.libsonnet",
+	}
+
+	cache := NewImportCache()
+	results := LoadAll(files, 2, Opts{JsonnetBundler: true, ImportCache: cache})
+	require.Len(t, results, len(files))
+
+	assert.Equal(t, files[0], results[0].File)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "bundled", results[0].Package.Name)
+	assert.Len(t, results[0].Diagnostics, 1)
+
+	assert.Error(t, results[1].Err)
+	assert.Nil(t, results[1].Package)
+
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, "old", results[2].Package.Name)
+	assert.Empty(t, results[2].Diagnostics)
+
+	assert.NotEmpty(t, cache.files)
+}
+
+func TestLoadAllSharedVM(t *testing.T) {
+	// a single goroutine loads all libraries using the same VM, whose importer
+	// is reconfigured for each of them
+	files := []string{
+		"testdata/bundler/main.libsonnet",
+		"testdata/docutil/main.libsonnet",
+		"testdata/bundler/main.libsonnet",
+	}
+
+	results := LoadAll(files, 1, Opts{JsonnetBundler: true})
+	for i, name := range []string{"bundled", "old", "bundled"} {
+		require.NoError(t, results[i].Err, files[i])
+		assert.Equal(t, name, results[i].Package.Name, files[i])
+	}
+}
+
+func TestLoadAllDocUtil(t *testing.T) {
+	files := []string{"testdata/docutil/main.libsonnet"}
+
+	// the bundled doc-util is not detected
+	results := LoadAll(files, 1, Opts{JsonnetBundler: true})
+	require.NoError(t, results[0].Err)
+	assert.Nil(t, results[0].DocUtil)
+
+	results = LoadAll(files, 1, Opts{JsonnetBundler: true, DocUtil: DocUtilVendored})
+	require.NoError(t, results[0].Err)
+	require.NoError(t, results[0].DocUtilErr)
+	require.NotNil(t, results[0].DocUtil)
+	assert.Equal(t, "v0.0.1", results[0].DocUtil.Version)
+	assert.Equal(t, "old", results[0].Package.Name)
+}
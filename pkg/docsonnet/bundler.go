@@ -0,0 +1,188 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// File names used by jsonnet-bundler
+const (
+	jsonnetfileName     = "jsonnetfile.json"
+	jsonnetfileLockName = "jsonnetfile.lock.json"
+	vendorDirName       = "vendor"
+)
+
+// bundle is a jsonnet-bundler project, along with the location of its
+// dependencies
+type bundle struct {
+	// root directory, holding jsonnetfile.json
+	root string
+	// vendor directory, dependencies are installed to
+	vendor string
+	// file the dependencies were read from
+	file string
+
+	deps []dependency
+}
+
+// dependency is a dependency installed by jsonnet-bundler
+type dependency struct {
+	// name is the import path of the dependency below vendor/, e.g.
+	// github.com/grafana/jsonnet-libs/ksonnet-util
+	name string
+	// legacy is the short name jsonnet-bundler links it as, e.g. ksonnet-util
+	legacy  string
+	version string
+	// dir the dependency is found in
+	dir string
+}
+
+func (d dependency) String() string {
+	if d.version == "" {
+		return d.name
+	}
+	return d.name + "@" + d.version
+}
+
+// installed reports whether the dependency is present on disk
+func (d dependency) installed() bool {
+	_, err := os.Stat(d.dir)
+	return err == nil
+}
+
+// jsonnetfile is the subset of jsonnetfile.json and jsonnetfile.lock.json
+// relevant for resolving imports
+type jsonnetfile struct {
+	Dependencies []struct {
+		Source struct {
+			Git *struct {
+				Remote string `json:"remote"`
+				Subdir string `json:"subdir"`
+			} `json:"git"`
+			Local *struct {
+				Directory string `json:"directory"`
+			} `json:"local"`
+		} `json:"source"`
+		Version string `json:"version"`
+		// Name overrides the legacy name
+		Name string `json:"name"`
+	} `json:"dependencies"`
+}
+
+// findBundle looks for jsonnetfile.json in the directory of `filename` and all
+// of its parents. Returns nil if none is found.
+func findBundle(filename string) (*bundle, error) {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, jsonnetfileName)); err == nil {
+			return loadBundle(dir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// loadBundle reads the dependencies of the jsonnet-bundler project at `root`,
+// preferring the lock file, which also lists transitive dependencies.
+func loadBundle(root string) (*bundle, error) {
+	file := filepath.Join(root, jsonnetfileLockName)
+	if _, err := os.Stat(file); err != nil {
+		file = filepath.Join(root, jsonnetfileName)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var jf jsonnetfile
+	if err := json.Unmarshal(data, &jf); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+
+	b := bundle{
+		root:   root,
+		vendor: filepath.Join(root, vendorDirName),
+		file:   file,
+	}
+
+	for _, d := range jf.Dependencies {
+		var dep dependency
+		switch {
+		case d.Source.Git != nil:
+			dep.name = path.Join(remotePath(d.Source.Git.Remote), d.Source.Git.Subdir)
+			dep.dir = filepath.Join(b.vendor, filepath.FromSlash(dep.name))
+		case d.Source.Local != nil:
+			dep.name = filepath.Base(d.Source.Local.Directory)
+			dep.dir = filepath.Join(root, d.Source.Local.Directory)
+		default:
+			continue
+		}
+
+		dep.version = d.Version
+		dep.legacy = path.Base(dep.name)
+		if d.Name != "" {
+			dep.legacy = d.Name
+		}
+
+		b.deps = append(b.deps, dep)
+	}
+
+	return &b, nil
+}
+
+// remotePath converts a git remote to the path jsonnet-bundler installs it
+// at, e.g. `https://github.com/grafana/jsonnet-libs.git` to
+// `github.com/grafana/jsonnet-libs`
+func remotePath(remote string) string {
+	if _, after, ok := strings.Cut(remote, "://"); ok {
+		remote = after
+	} else {
+		// scp-like syntax: git@github.com:grafana/jsonnet-libs.git
+		remote = strings.Replace(remote, ":", "/", 1)
+	}
+
+	if _, after, ok := strings.Cut(remote, "@"); ok {
+		remote = after
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+}
+
+// resolve maps `importedPath` to a file of a dependency, using either its
+// full or legacy name. Used for imports that could not be found in the JPath,
+// e.g. because jsonnet-bundler did not create the legacy symlinks.
+func (b *bundle) resolve(importedPath string) (string, *dependency) {
+	for i, d := range b.deps {
+		for _, prefix := range []string{d.name, d.legacy} {
+			if strings.HasPrefix(importedPath, prefix+"/") {
+				rest := strings.TrimPrefix(importedPath, prefix+"/")
+				return filepath.Join(d.dir, filepath.FromSlash(rest)), &b.deps[i]
+			}
+		}
+	}
+	return "", nil
+}
+
+// missing returns the dependencies that are not installed
+func (b *bundle) missing() []dependency {
+	var out []dependency
+	for _, d := range b.deps {
+		if !d.installed() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package docsonnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/go-jsonnet"
+	"github.com/markbates/pkger"
+)
+
+// ImportCache caches the files imported while loading libraries. Sharing one
+// between calls to Load (using Opts.ImportCache) reads each file only once,
+// which pays off when documenting many libraries that share a vendor tree.
+// It is safe for concurrent use.
+type ImportCache struct {
+	mu    sync.Mutex
+	files map[string]*cachedFile
+
+	// util is the doc-util bundled with the binary
+	util cachedFile
+}
+
+// NewImportCache returns an empty ImportCache
+func NewImportCache() *ImportCache {
+	return &ImportCache{files: make(map[string]*cachedFile)}
+}
+
+type cachedFile struct {
+	once     sync.Once
+	contents jsonnet.Contents
+	err      error
+}
+
+// read returns the contents of the file at `name`, reading it on first use
+func (c *ImportCache) read(name string) (jsonnet.Contents, error) {
+	key, err := filepath.Abs(name)
+	if err != nil {
+		return jsonnet.Contents{}, err
+	}
+
+	c.mu.Lock()
+	f, ok := c.files[key]
+	if !ok {
+		f = &cachedFile{}
+		c.files[key] = f
+	}
+	c.mu.Unlock()
+
+	f.once.Do(func() {
+		data, err := os.ReadFile(key)
+		if err != nil {
+			f.err = err
+			return
+		}
+		f.contents = jsonnet.MakeContents(string(data))
+	})
+	return f.contents, f.err
+}
+
+// bundledDocUtil returns the contents of the doc-util bundled with the binary.
+// The same contents are returned each time, as a Jsonnet VM requires when it
+// is reused.
+func (c *ImportCache) bundledDocUtil() (jsonnet.Contents, error) {
+	c.util.once.Do(func() {
+		file, err := pkger.Open("/doc-util/main.libsonnet")
+		if err != nil {
+			c.util.err = err
+			return
+		}
+		data, err := io.ReadAll(file)
+		if err != nil {
+			c.util.err = err
+			return
+		}
+		c.util.contents = jsonnet.MakeContents(string(data))
+	})
+	return c.util.contents, c.util.err
+}
+
+// find resolves `importedPath` like jsonnet.FileImporter does: relative to the
+// importing file first, then in `jpaths`, right-most first
+func (c *ImportCache) find(jpaths []string, importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	dir, _ := filepath.Split(importedFrom)
+	dirs := []string{dir}
+	for i := len(jpaths) - 1; i >= 0; i-- {
+		dirs = append(dirs, jpaths[i])
+	}
+
+	for _, dir := range dirs {
+		foundAt := importedPath
+		if !filepath.IsAbs(importedPath) {
+			foundAt = filepath.Join(dir, importedPath)
+		}
+
+		contents, err := c.read(foundAt)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return jsonnet.Contents{}, "", err
+		}
+		return contents, foundAt, nil
+	}
+
+	return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %#v: no match locally or in the Jsonnet library paths", importedPath)
+}
@@ -0,0 +1,77 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Coverage tells which fields of a library are documented. Only fields of
+// objects that have at least one docstring are considered, as others are
+// usually implementation details.
+type Coverage struct {
+	Documented   []string `json:"documented"`
+	Undocumented []string `json:"undocumented"`
+}
+
+// Ratio of documented fields, between 0 and 1. A library without any fields is
+// fully covered.
+func (c Coverage) Ratio() float64 {
+	total := len(c.Documented) + len(c.Undocumented)
+	if total == 0 {
+		return 1
+	}
+	return float64(len(c.Documented)) / float64(total)
+}
+
+// MeasureCoverage evaluates the library at `filename` and reports which of its
+// fields are documented. Fields marked as `ignore` are left out.
+func MeasureCoverage(filename string, opts Opts) (*Coverage, error) {
+	vm, err := newVM(filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	vm.ExtCode(mainExtVar, mainCode(filename, opts))
+
+	data, err := vm.EvaluateAnonymousSnippet("coverage.libsonnet", coverageCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []struct {
+		Path       string `json:"path"`
+		Documented bool   `json:"documented"`
+	}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return nil, err
+	}
+
+	var c Coverage
+	for _, f := range fields {
+		if f.Documented {
+			c.Documented = append(c.Documented, f.Path)
+		} else {
+			c.Undocumented = append(c.Undocumented, f.Path)
+		}
+	}
+	sort.Strings(c.Documented)
+	sort.Strings(c.Undocumented)
+	return &c, nil
+}
+
+// coverageCode lists all fields of the library, below objects that have at least
+// one docstring, and whether they are documented
+const coverageCode = `
+local walk(obj, path) =
+  local fields = std.objectFieldsAll(obj);
+  local documented = std.length([f for f in fields if std.startsWith(f, '#')]) > 0;
+  local ignored(f) = std.objectHasAll(obj, '#' + f) && obj['#' + f] == 'ignore';
+  std.flattenArrays([
+    local p = if path == '' then f else path + '.' + f;
+    (if documented then [{ path: p, documented: std.objectHasAll(obj, '#' + f) }] else [])
+    + (if std.isObject(obj[f]) then walk(obj[f], p) else [])
+    for f in fields
+    if !std.startsWith(f, '#') && !ignored(f)
+  ]);
+
+walk(std.extVar('__docsonnet_main'), '')
+`
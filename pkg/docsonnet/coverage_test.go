@@ -0,0 +1,19 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasureCoverage(t *testing.T) {
+	c, err := MeasureCoverage("testdata/coverage/main.libsonnet", Opts{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"nested", "nested.withReplicas", "new"}, c.Documented)
+	assert.Equal(t, []string{"nested.withImage", "util", "withName"}, c.Undocumented)
+	assert.Equal(t, 0.5, c.Ratio())
+
+	assert.Equal(t, 1.0, Coverage{}.Ratio())
+}
@@ -0,0 +1,137 @@
+package docsonnet
+
+import "fmt"
+
+// Severity of a Diagnostic
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Rules identifying the kind of problem a Diagnostic reports
+const (
+	// RuleInvalidType is reported for type expressions that can't be parsed
+	RuleInvalidType = "invalid-type"
+	// RuleUnknownType is reported for types that are none of the Type*
+	// constants and name no documented object or package
+	RuleUnknownType = "unknown-type"
+	// RuleKindMismatch is reported if a docstring describes a different kind
+	// of field (function, object, value) than the field actually is
+	RuleKindMismatch = "kind-mismatch"
+	// RuleStaticEval is reported by ExtractStatic for docstrings it can't
+	// evaluate
+	RuleStaticEval = "static-eval"
+	// RuleMissingDependency is reported for dependencies listed in
+	// jsonnetfile.json or jsonnetfile.lock.json that are not installed
+	RuleMissingDependency = "missing-dependency"
+)
+
+// Diagnostic is a problem found in the docsonnet data, that did not prevent
+// it from being loaded.
+type Diagnostic struct {
+	Severity Severity
+	// Rule identifies the kind of problem, e.g. RuleUnknownType
+	Rule string
+	// Path is the dotted path of the affected field, if any
+	Path string
+	// Message describes the problem
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	s := d.Severity.String() + ": "
+	if d.Path != "" {
+		s += d.Path + ": "
+	}
+	return fmt.Sprintf("%s%s (%s)", s, d.Message, d.Rule)
+}
+
+// Diagnostics is a list of Diagnostic's, usually reported while loading
+type Diagnostics []Diagnostic
+
+// Warnf appends a warning about `path`
+func (d *Diagnostics) Warnf(rule, path, format string, a ...interface{}) {
+	*d = append(*d, Diagnostic{
+		Severity: SeverityWarning,
+		Rule:     rule,
+		Path:     path,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// Rules lists all rules
+var Rules = []string{
+	RuleInvalidType,
+	RuleUnknownType,
+	RuleKindMismatch,
+	RuleStaticEval,
+	RuleMissingDependency,
+}
+
+// LintRules configures the severity of diagnostics by rule, as either "off",
+// "warning" or "error". Rules that are not listed keep their default severity.
+type LintRules map[string]string
+
+// RuleOff disables a rule in LintRules
+const RuleOff = "off"
+
+// Validate checks that only known rules and severities are used
+func (r LintRules) Validate() error {
+	for rule, sev := range r {
+		known := false
+		for _, k := range Rules {
+			known = known || k == rule
+		}
+		if !known {
+			return fmt.Errorf("unknown lint rule '%s'", rule)
+		}
+
+		switch sev {
+		case RuleOff, SeverityWarning.String(), SeverityError.String():
+		default:
+			return fmt.Errorf("lint rule '%s': severity must be one of off, warning, error. Got '%s'", rule, sev)
+		}
+	}
+	return nil
+}
+
+// Apply returns `d` with the severities configured by r. Diagnostics of rules
+// that are off are dropped.
+func (r LintRules) Apply(d Diagnostics) Diagnostics {
+	out := make(Diagnostics, 0, len(d))
+	for _, diag := range d {
+		switch r[diag.Rule] {
+		case RuleOff:
+			continue
+		case SeverityWarning.String():
+			diag.Severity = SeverityWarning
+		case SeverityError.String():
+			diag.Severity = SeverityError
+		}
+		out = append(out, diag)
+	}
+	return out
+}
+
+// Errors returns the number of diagnostics with SeverityError
+func (d Diagnostics) Errors() int {
+	n := 0
+	for _, diag := range d {
+		if diag.Severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,29 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintRules(t *testing.T) {
+	var d Diagnostics
+	d.Warnf(RuleUnknownType, "new(name)", "unknown type 'strng'")
+	d.Warnf(RuleStaticEval, "new", "docstring refers to self")
+	d.Warnf(RuleKindMismatch, "replicas", "documented as function, but is of type number")
+
+	rules := LintRules{
+		RuleUnknownType: "error",
+		RuleStaticEval:  "off",
+	}
+	assert.NoError(t, rules.Validate())
+
+	got := rules.Apply(d)
+	assert.Len(t, got, 2)
+	assert.Equal(t, SeverityError, got[0].Severity)
+	assert.Equal(t, SeverityWarning, got[1].Severity)
+	assert.Equal(t, 1, got.Errors())
+
+	assert.Error(t, LintRules{"no-such-rule": "error"}.Validate())
+	assert.Error(t, LintRules{RuleUnknownType: "fatal"}.Validate())
+}
@@ -0,0 +1,123 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind tells how a field changed between two versions of a package
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change of a single field between two versions of a package
+type Change struct {
+	Kind ChangeKind
+	// Path of the field, prefixed by the names of sub packages
+	Path string
+	// Old and New signature of the field. Empty if it was added or removed.
+	Old, New string
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return "+ " + c.New
+	case ChangeRemoved:
+		return "- " + c.Old
+	}
+	return fmt.Sprintf("~ %s -> %s", c.Old, c.New)
+}
+
+// Diff compares the API of two versions of a package, returning the fields
+// that were added, removed or whose signature changed, sorted by path. Changes
+// of help texts are not reported.
+func Diff(old, new Package) []Change {
+	a, b := make(map[string]string), make(map[string]string)
+	flattenPackage(old, "", a)
+	flattenPackage(new, "", b)
+
+	var changes []Change
+	for path, sig := range a {
+		other, ok := b[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: path, Old: sig})
+		case other != sig:
+			changes = append(changes, Change{Kind: ChangeChanged, Path: path, Old: sig, New: other})
+		}
+	}
+	for path, sig := range b {
+		if _, ok := a[path]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Path: path, New: sig})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// flattenPackage adds the signatures of all fields of `pkg` to `out`
+func flattenPackage(pkg Package, path string, out map[string]string) {
+	flattenFields(pkg.API, path, out)
+	for name, sub := range pkg.Sub {
+		flattenPackage(sub, joinPath(path, name), out)
+	}
+}
+
+func flattenFields(fields Fields, path string, out map[string]string) {
+	for name, f := range fields {
+		p := joinPath(path, name)
+		out[p] = signature(p, f)
+		if f.Object != nil {
+			flattenFields(f.Object.Fields, p, out)
+		}
+	}
+}
+
+// signature describes the field `f` at `path`, e.g. `fn new(name: string)`
+func signature(path string, f Field) string {
+	switch {
+	case f.Function != nil:
+		args := make([]string, len(f.Function.Args))
+		for i, a := range f.Function.Args {
+			args[i] = a.Name
+			if a.Type != "" {
+				args[i] += ": " + string(a.Type)
+			}
+			if a.Default != nil {
+				args[i] += "=" + defaultString(a.Default)
+			}
+		}
+		s := fmt.Sprintf("fn %s(%s)", path, strings.Join(args, ", "))
+		if r := f.Function.Return; r != nil && r.Type != "" {
+			s += ": " + string(r.Type)
+		}
+		return s
+	case f.Object != nil:
+		return "obj " + path
+	case f.Value != nil:
+		s := fmt.Sprintf("%s %s", f.Value.Type, path)
+		if f.Value.Default != nil {
+			s += " = " + defaultString(f.Value.Default)
+		}
+		return s
+	}
+	return path
+}
+
+func defaultString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,41 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := Package{
+		Name: "lib",
+		API: Fields{
+			"new":      {Function: &Function{Name: "new", Args: []Argument{{Name: "name", Type: TypeString}}}},
+			"old":      {Function: &Function{Name: "old"}},
+			"replicas": {Value: &Value{Name: "replicas", Type: TypeNumber, Default: 1.0}},
+		},
+	}
+	new := Package{
+		Name: "lib",
+		API: Fields{
+			"new": {Function: &Function{Name: "new", Args: []Argument{
+				{Name: "name", Type: TypeString},
+				{Name: "namespace", Default: "default"},
+			}}},
+			"replicas": {Value: &Value{Name: "replicas", Type: TypeNumber, Default: 1.0, Help: "changed"}},
+		},
+		Sub: map[string]Package{
+			"sub": {Name: "sub", API: Fields{
+				"obj": {Object: &Object{Name: "obj", Fields: Fields{}}},
+			}},
+		},
+	}
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeChanged, Path: "new", Old: "fn new(name: string)", New: `fn new(name: string, namespace="default")`},
+		{Kind: ChangeRemoved, Path: "old", Old: "fn old()"},
+		{Kind: ChangeAdded, Path: "sub.obj", New: "obj sub.obj"},
+	}, Diff(old, new))
+
+	assert.Empty(t, Diff(new, new))
+}
@@ -0,0 +1,157 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Values of Opts.DocUtil, besides a path
+const (
+	// DocUtilBundled always uses the doc-util bundled with docsonnet
+	DocUtilBundled = "bundled"
+	// DocUtilVendored uses the doc-util the library resolves itself, e.g.
+	// from vendor/. The bundled one is used if there is none.
+	DocUtilVendored = "vendored"
+)
+
+// docUtilFile returns the main.libsonnet of the doc-util at `path`, or the mode
+// if `path` is one of DocUtilBundled and DocUtilVendored
+func docUtilFile(path string) (string, error) {
+	switch path {
+	case "", DocUtilBundled, DocUtilVendored:
+		return path, nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("doc-util: %w", err)
+	}
+	if fi.IsDir() {
+		path = filepath.Join(path, "main.libsonnet")
+	}
+	return filepath.Abs(path)
+}
+
+// DocUtil describes the doc-util library a library was written against
+type DocUtil struct {
+	// Path of its main.libsonnet
+	Path string `json:"path"`
+	// Version as locked in jsonnetfile.lock.json, if known
+	Version string `json:"version,omitempty"`
+	// Features of the docsonnet model this version supports
+	Features DocUtilFeatures `json:"features"`
+}
+
+// DocUtilFeatures are the parts of the docsonnet model that only newer versions
+// of doc-util support. Libraries written against older versions simply don't
+// have this information, so it is missing from their docs as well.
+type DocUtilFeatures struct {
+	// Enums of arguments, `d.arg(enums=[...])`
+	Enums bool `json:"enums"`
+	// Schema of arguments, `d.argument.fromSchema()`
+	Schema bool `json:"schema"`
+	// Return types of functions, `d.func.withReturn()`
+	Return bool `json:"return"`
+	// Union and collection types, `d.T.union()`, `d.T.arrayOf()`, ...
+	Union bool `json:"union"`
+}
+
+func (d DocUtil) String() string {
+	if d.Version == "" {
+		return d.Path
+	}
+	return fmt.Sprintf("%s (version %s)", d.Path, d.Version)
+}
+
+// Unsupported lists the features this doc-util lacks
+func (d DocUtil) Unsupported() []string {
+	var out []string
+	for _, f := range []struct {
+		ok   bool
+		name string
+	}{
+		{d.Features.Enums, "argument enums"},
+		{d.Features.Schema, "argument schemas"},
+		{d.Features.Return, "return types"},
+		{d.Features.Union, "union types"},
+	} {
+		if !f.ok {
+			out = append(out, f.name)
+		}
+	}
+	return out
+}
+
+// DetectDocUtil finds the doc-util the library at `filename` imports, when not
+// overridden by the bundled one: the one in its JPath, or installed by
+// jsonnet-bundler if opts.JsonnetBundler is set. If opts.DocUtil is a path,
+// that one is described instead. Returns nil if the library does not provide a
+// doc-util itself.
+func DetectDocUtil(filename string, opts Opts) (*DocUtil, error) {
+	imp, err := newImporter(filename, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var foundAt string
+	switch imp.docUtil {
+	case "", DocUtilBundled, DocUtilVendored:
+		for _, p := range docUtilPaths {
+			if _, at, err := imp.importFile(filename, p); err == nil {
+				foundAt = at
+				break
+			}
+		}
+	default:
+		foundAt = imp.docUtil
+	}
+	if foundAt == "" {
+		return nil, nil
+	}
+
+	if foundAt, err = filepath.Abs(foundAt); err != nil {
+		return nil, err
+	}
+	d := DocUtil{Path: foundAt}
+
+	if imp.bundle != nil {
+		for _, dep := range imp.bundle.deps {
+			if strings.HasPrefix(foundAt, dep.dir+string(filepath.Separator)) {
+				d.Version = dep.version
+			}
+		}
+	}
+
+	vm := opts.vmFor(imp)
+	data, err := vm.EvaluateAnonymousSnippet("doc-util-features.jsonnet", fmt.Sprintf(docUtilFeaturesCode, strings.ReplaceAll(foundAt, "'", "''")))
+	if err != nil {
+		return nil, fmt.Errorf("detecting features of doc-util at %s: %w", foundAt, err)
+	}
+	if err := json.Unmarshal([]byte(data), &d.Features); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// ownDocUtil reports whether `opts` may load another doc-util than the bundled
+// one
+func ownDocUtil(opts Opts) bool {
+	return opts.DocUtil != "" && opts.DocUtil != DocUtilBundled
+}
+
+// docUtilFeaturesCode evaluates to the DocUtilFeatures of the doc-util at %s
+const docUtilFeaturesCode = `
+local d = import @'%s';
+local has(o, ks) = std.length(ks) == 0 || (std.isObject(o) && std.objectHasAll(o, ks[0]) && has(o[ks[0]], ks[1:]));
+{
+  enums: has(d, ['argument', '#new', 'function', 'args'])
+         && std.length([a for a in d.argument['#new']['function'].args if a.name == 'enums']) > 0,
+  schema: has(d, ['argument', 'fromSchema']),
+  'return': has(d, ['func', 'withReturn']),
+  union: has(d, ['T', 'union']),
+}
+`
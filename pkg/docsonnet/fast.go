@@ -6,14 +6,45 @@ import (
 	"strings"
 )
 
+// loader converts the raw extracted data into the docsonnet model, collecting
+// diagnostics along the way
+type loader struct {
+	diags Diagnostics
+
+	// scope is the path of the package being loaded
+	scope string
+	// unknown are the types naming no built-in type, checked against the
+	// documented objects once all are loaded
+	unknown []typeUse
+}
+
+// typeUse is the type `t` of the field at `path`, in the package `scope`
+type typeUse struct {
+	path, scope string
+	t           Type
+}
+
 // load docsonnet
 //
 // Data assumptions:
 // - only map[string]interface{} and fields
 // - fields (#...) coming first
-func fastLoad(d ds) Package {
+func fastLoad(d ds) (Package, Diagnostics) {
+	var l loader
+	pkg := l.loadPackage(d, "")
+	l.checkTypes(pkg)
+	return pkg, l.diags
+}
+
+// loadPackage loads the package `d`, with `path` being its location relative
+// to the root package
+func (l *loader) loadPackage(d ds, path string) Package {
 	pkg := d.Package()
 
+	scope := l.scope
+	l.scope = path
+	defer func() { l.scope = scope }()
+
 	pkg.API = make(Fields)
 	pkg.Sub = make(map[string]Package)
 
@@ -27,19 +58,20 @@ func fastLoad(d ds) Package {
 
 		// is it a docstring?
 		if strings.HasPrefix(k, "#") {
-			pkg.API[n] = loadField(n, f, d)
+			pkg.API[n] = l.loadField(joinPath(path, n), n, f, d)
 			continue
 		}
 
 		// is it a package?
 		if _, ok := f["#"]; ok {
-			p := fastLoad(ds(f))
+			sub := ds(f)
+			p := l.loadPackage(sub, joinPath(path, sub.Package().Name))
 			pkg.Sub[p.Name] = p
 			continue
 		}
 
 		// is it a regular field? check nested...
-		if nested, ok := loadNested(n, f); ok && !hasDocstring(n, d) {
+		if nested, ok := l.loadNested(joinPath(path, n), n, f); ok && !hasDocstring(n, d) {
 			pkg.API[n] = *nested
 		}
 	}
@@ -52,7 +84,18 @@ func hasDocstring(key string, msi map[string]interface{}) bool {
 	return ok
 }
 
-func loadNested(name string, msi map[string]interface{}) (*Field, bool) {
+// joinPath appends `name` to the dotted field path `parent`
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
+
+func (l *loader) loadNested(path, name string, msi map[string]interface{}) (*Field, bool) {
 	out := Object{
 		Name:   name,
 		Fields: make(Fields),
@@ -64,12 +107,12 @@ func loadNested(name string, msi map[string]interface{}) (*Field, bool) {
 
 		// is it a docstring?
 		if strings.HasPrefix(k, "#") {
-			out.Fields[n] = loadField(n, f, msi)
+			out.Fields[n] = l.loadField(joinPath(path, n), n, f, msi)
 			continue
 		}
 
 		// is it a regular field? check nested...
-		if nested, ok := loadNested(n, f); ok && !hasDocstring(n, msi) {
+		if nested, ok := l.loadNested(joinPath(path, n), n, f); ok && !hasDocstring(n, msi) {
 			out.Fields[n] = *nested
 		}
 	}
@@ -77,23 +120,74 @@ func loadNested(name string, msi map[string]interface{}) (*Field, bool) {
 	return &Field{Object: &out}, true
 }
 
-func loadField(name string, field map[string]interface{}, parent map[string]interface{}) Field {
-	if ifn, ok := field["function"]; ok {
-		return loadFn(name, ifn.(map[string]interface{}))
+func (l *loader) loadField(path, name string, field map[string]interface{}, parent map[string]interface{}) Field {
+	var f Field
+	switch {
+	case field["function"] != nil:
+		f = l.loadFn(path, name, field["function"].(map[string]interface{}))
+	case field["object"] != nil:
+		f = l.loadObj(path, name, field["object"].(map[string]interface{}), parent)
+	case field["value"] != nil:
+		f = l.loadValue(path, name, field["value"].(map[string]interface{}))
+	default:
+		panic(fmt.Sprintf("field %s lacking {function | object | value}", name))
 	}
 
-	if iobj, ok := field["object"]; ok {
-		return loadObj(name, iobj.(map[string]interface{}), parent)
+	if rt, ok := field["runtime"].(map[string]interface{}); ok {
+		f.Runtime = l.loadRuntime(path, f, rt)
+	}
+	if loc, ok := field["location"].(map[string]interface{}); ok {
+		f.Location = loadLocation(loc)
+	}
+	if group, ok := field["group"].(string); ok {
+		f.Group = group
+	}
+	if order, ok := field["order"].(float64); ok {
+		f.Order = &order
 	}
 
-	if vobj, ok := field["value"]; ok {
-		return loadValue(name, vobj.(map[string]interface{}))
+	return f
+}
+
+func loadLocation(msi map[string]interface{}) *Location {
+	file, _ := msi["file"].(string)
+	line, _ := msi["line"].(float64)
+	column, _ := msi["column"].(float64)
+	return &Location{
+		File:   file,
+		Line:   int(line),
+		Column: int(column),
+	}
+}
+
+// loadRuntime loads what was observed about the documented field during
+// evaluation, reporting docstrings that don't match the actual field
+func (l *loader) loadRuntime(path string, f Field, msi map[string]interface{}) *Runtime {
+	vis, _ := msi["visibility"].(string)
+	t, _ := msi["type"].(string)
+	rt := Runtime{
+		Visibility: Visibility(vis),
+		Type:       Type(t),
+	}
+
+	var documented string
+	var ok bool
+	switch {
+	case f.Function != nil:
+		documented, ok = "function", t == TypeFunc
+	case f.Object != nil:
+		documented, ok = "object", t == TypeObject
+	case f.Value != nil:
+		documented, ok = "value", t != TypeFunc
+	}
+	if t != "" && !ok {
+		l.diags.Warnf(RuleKindMismatch, path, "documented as %s, but is of type %s", documented, t)
 	}
 
-	panic(fmt.Sprintf("field %s lacking {function | object | value}", name))
+	return &rt
 }
 
-func loadValue(name string, msi map[string]interface{}) Field {
+func (l *loader) loadValue(path, name string, msi map[string]interface{}) Field {
 	h, ok := msi["help"].(string)
 	if !ok {
 		h = ""
@@ -107,14 +201,14 @@ func loadValue(name string, msi map[string]interface{}) Field {
 	v := Value{
 		Name:    name,
 		Help:    h,
-		Type:    Type(t),
+		Type:    l.loadType(path, t),
 		Default: msi["default"],
 	}
 
 	return Field{Value: &v}
 }
 
-func loadFn(name string, msi map[string]interface{}) Field {
+func (l *loader) loadFn(path, name string, msi map[string]interface{}) Field {
 	h, ok := msi["help"].(string)
 	if !ok {
 		h = ""
@@ -124,24 +218,133 @@ func loadFn(name string, msi map[string]interface{}) Field {
 		Help: h,
 	}
 	if args, ok := msi["args"]; ok {
-		fn.Args = loadArgs(args.([]interface{}))
+		fn.Args = l.loadArgs(path, args.([]interface{}))
+	}
+	if ret, ok := msi["return"].(map[string]interface{}); ok {
+		fn.Return = l.loadReturn(path, ret)
 	}
 	return Field{Function: &fn}
 }
 
-func loadArgs(is []interface{}) []Argument {
+func (l *loader) loadReturn(path string, msi map[string]interface{}) *Return {
+	t, ok := msi["type"].(string)
+	if !ok {
+		return nil
+	}
+	h, ok := msi["help"].(string)
+	if !ok {
+		h = ""
+	}
+	return &Return{
+		Type: l.loadType(path, t),
+		Help: h,
+	}
+}
+
+func (l *loader) loadArgs(path string, is []interface{}) []Argument {
 	args := make([]Argument, len(is))
 	for i := range is {
 		arg := is[i].(map[string]interface{})
+		name := arg["name"].(string)
+		t, _ := arg["type"].(string)
+		enums, _ := arg["enums"].([]interface{})
+		schema, _ := arg["schema"].(map[string]interface{})
+
+		// older doc-util versions lack enums and schemas, newer ones may
+		// describe the argument using the schema only
+		def := arg["default"]
+		if schema != nil {
+			if t == "" {
+				t = schemaType(schema)
+			}
+			if def == nil {
+				def = schema["default"]
+			}
+			if enums == nil {
+				enums, _ = schema["enum"].([]interface{})
+			}
+		}
+
 		args[i] = Argument{
-			Name:    arg["name"].(string),
-			Type:    Type(arg["type"].(string)),
-			Default: arg["default"],
+			Name:    name,
+			Type:    l.loadType(path+"("+name+")", t),
+			Default: def,
+			Enums:   enums,
+			Schema:  schema,
 		}
 	}
 	return args
 }
 
+// schemaType returns the type expression of the JSON schema `schema`, whose
+// type is either a single type or a list of those
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		names := make([]string, 0, len(t))
+		for _, n := range t {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return strings.Join(names, " | ")
+	}
+	return ""
+}
+
+// loadType parses the type expression `s` and normalizes it to the canonical
+// type names. Expressions that fail to parse are kept verbatim as the name of a
+// simple type. Invalid expressions are reported, unknown types by checkTypes.
+func (l *loader) loadType(path, s string) Type {
+	t, err := ParseType(s)
+	if err != nil {
+		l.diags.Warnf(RuleInvalidType, path, "invalid type expression: %s", err)
+		return Type(s)
+	}
+
+	typ := t.Normalize().Type()
+	if typ != "" && !typ.Valid() {
+		l.unknown = append(l.unknown, typeUse{path: path, scope: l.scope, t: typ})
+	}
+	return typ
+}
+
+// checkTypes reports the types that name neither built-in types nor documented
+// objects or packages of `root`. Like when rendering, names are looked up
+// relative to the package of the field first, and then relative to `root`.
+func (l *loader) checkTypes(root Package) {
+	for _, u := range l.unknown {
+		if !documentedType(root, u.scope, u.t.Expr()) {
+			l.diags.Warnf(RuleUnknownType, u.path, "unknown type '%s'", u.t)
+		}
+	}
+}
+
+func documentedType(root Package, scope string, t TypeExpr) bool {
+	switch {
+	case t.IsUnion():
+		for _, m := range t.Union {
+			if !documentedType(root, scope, m) {
+				return false
+			}
+		}
+		return true
+	case t.Elem != nil:
+		return (t.Name == TypeArray || t.Name == TypeObject) && documentedType(root, scope, *t.Elem)
+	case t.Valid():
+		return true
+	}
+
+	for _, path := range []string{joinPath(scope, t.Name), t.Name} {
+		if f, pkg := root.Lookup(path); pkg != nil || (f != nil && f.Object != nil) {
+			return true
+		}
+	}
+	return false
+}
+
 func fieldNames(msi map[string]interface{}) []string {
 	out := make([]string, 0, len(msi))
 	for k := range msi {
@@ -150,7 +353,7 @@ func fieldNames(msi map[string]interface{}) []string {
 	return out
 }
 
-func loadObj(name string, msi map[string]interface{}, parent map[string]interface{}) Field {
+func (l *loader) loadObj(path, name string, msi map[string]interface{}, parent map[string]interface{}) Field {
 	obj := Object{
 		Name:   name,
 		Help:   msi["help"].(string),
@@ -166,7 +369,7 @@ func loadObj(name string, msi map[string]interface{}, parent map[string]interfac
 	}
 
 	childs := iChilds.(map[string]interface{})
-	if nested, ok := loadNested(name, childs); ok {
+	if nested, ok := l.loadNested(path, name, childs); ok {
 		obj.Fields = nested.Object.Fields
 	}
 
@@ -0,0 +1,118 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformDiagnostics(t *testing.T) {
+	data := []byte(`{
+  "#": {"name": "lib", "import": "lib.libsonnet", "help": ""},
+  "#enabled": {"value": {"type": "bool", "help": ""}},
+  "#new": {"function": {"help": "", "args": [
+    {"name": "name", "type": "strng", "default": null},
+    {"name": "opts", "type": "object<", "default": null}
+  ]}}
+}`)
+
+	pkg, diags, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, Type(TypeBool), pkg.API["enabled"].Value.Type)
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityWarning, Rule: RuleInvalidType, Path: "new(opts)", Message: "invalid type expression: unexpected end of type expression"},
+		{Severity: SeverityWarning, Rule: RuleUnknownType, Path: "new(name)", Message: "unknown type 'strng'"},
+	}, diags)
+}
+
+func TestTransformDocumentedTypes(t *testing.T) {
+	data := []byte(`{
+  "#": {"name": "lib", "import": "lib.libsonnet", "help": ""},
+  "dashboard": {
+    "#": {"name": "dashboard", "import": "", "help": ""},
+    "#new": {"function": {"help": "", "args": [
+      {"name": "p", "type": "panel.Panel|array<panel.Panel>", "default": null},
+      {"name": "t", "type": "target", "default": null},
+      {"name": "x", "type": "panel.Nope", "default": null}
+    ]}},
+    "#target": {"object": {"help": ""}},
+    "target": {}
+  },
+  "panel": {
+    "#": {"name": "panel", "import": "", "help": ""},
+    "#Panel": {"object": {"help": ""}},
+    "Panel": {}
+  }
+}`)
+
+	_, diags, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+
+	// objects are looked up relative to the package of the field, then the root
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityWarning, Rule: RuleUnknownType, Path: "dashboard.new(x)", Message: "unknown type 'panel.Nope'"},
+	}, diags)
+}
+
+func TestTransformRuntime(t *testing.T) {
+	data := []byte(`{
+  "#": {"name": "lib", "import": "lib.libsonnet", "help": ""},
+  "#new": {"function": {"help": ""}, "runtime": {"visibility": "hidden", "type": "function"}},
+  "#replicas": {"function": {"help": ""}, "runtime": {"visibility": "visible", "type": "number"}}
+}`)
+
+	pkg, diags, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Runtime{Visibility: VisibilityHidden, Type: TypeFunc}, pkg.API["new"].Runtime)
+	assert.Equal(t, &Runtime{Visibility: VisibilityVisible, Type: TypeNumber}, pkg.API["replicas"].Runtime)
+	assert.Equal(t, Diagnostics{
+		{Severity: SeverityWarning, Rule: RuleKindMismatch, Path: "replicas", Message: "documented as function, but is of type number"},
+	}, diags)
+}
+
+func TestTransformArgSchema(t *testing.T) {
+	data := []byte(`{
+  "#": {"name": "lib", "import": "lib.libsonnet", "help": ""},
+  "#new": {"function": {"help": "", "args": [
+    {"name": "replicas", "type": "number", "default": 1, "enums": [1, 3]},
+    {"name": "mode", "schema": {"type": ["string", "null"], "enum": ["a", "b"], "default": "a"}},
+    {"name": "legacy", "type": "string", "default": null}
+  ]}}
+}`)
+
+	pkg, diags, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+
+	args := pkg.API["new"].Function.Args
+	assert.Equal(t, []interface{}{1.0, 3.0}, args[0].Enums)
+
+	assert.Equal(t, Type("string | null"), args[1].Type)
+	assert.Equal(t, []interface{}{"a", "b"}, args[1].Enums)
+	assert.Equal(t, "a", args[1].Default)
+
+	assert.Nil(t, args[2].Enums)
+	assert.Nil(t, args[2].Schema)
+}
+
+func TestTransformGroupOrder(t *testing.T) {
+	data := []byte(`{
+  "#": {"name": "lib", "import": "lib.libsonnet", "help": ""},
+  "#new": {"function": {"help": ""}, "group": "Constructors", "order": 1},
+  "#withName": {"function": {"help": ""}, "group": "Modifiers"},
+  "#name": {"value": {"type": "string", "help": ""}}
+}`)
+
+	pkg, _, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+
+	one := 1.0
+	assert.Equal(t, "Constructors", pkg.API["new"].Group)
+	assert.Equal(t, &one, pkg.API["new"].Order)
+	assert.Equal(t, "Modifiers", pkg.API["withName"].Group)
+	assert.Nil(t, pkg.API["withName"].Order)
+	assert.Equal(t, "", pkg.API["name"].Group)
+}
@@ -1,6 +1,7 @@
 package docsonnet
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 )
@@ -13,6 +14,20 @@ type Field struct {
 	Object *Object `json:"object,omitempty"`
 	// Any other value
 	Value *Value `json:"value,omitempty"`
+
+	// Runtime describes the documented field as found during evaluation. Not
+	// set for fields that lack a docstring.
+	Runtime *Runtime `json:"runtime,omitempty"`
+	// Location of the docstring in the Jsonnet source. Only known when using
+	// ExtractStatic.
+	Location *Location `json:"location,omitempty"`
+
+	// Group is the name of the group the field is listed in, like
+	// "Constructors", set using `d.group`
+	Group string `json:"group,omitempty"`
+	// Order is the position of the field among the others of its object or
+	// group, set using `d.order`. Nil if not set.
+	Order *float64 `json:"order,omitempty"`
 }
 
 func (o *Field) UnmarshalJSON(data []byte) error {
@@ -33,6 +48,10 @@ func (o *Field) UnmarshalJSON(data []byte) error {
 	default:
 		return errors.New("field has no value")
 	}
+	o.Runtime = f.Runtime
+	o.Location = f.Location
+	o.Group = f.Group
+	o.Order = f.Order
 
 	return nil
 }
@@ -43,7 +62,19 @@ func (o Field) MarshalJSON() ([]byte, error) {
 	}
 
 	type fake Field
-	return json.Marshal(fake(o))
+	return marshalJSON(fake(o))
+}
+
+// marshalJSON is like json.Marshal, but does not escape HTML characters, as
+// those are common in type expressions (`array<string>`) and help texts.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
 }
 
 // Fields is a list of fields
@@ -0,0 +1,124 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ModelVersion is the version of the JSON interchange format written by
+// Encode. It is increased on every incompatible change, along with a migration
+// from the previous version.
+const ModelVersion = 1
+
+// ModelSchema is the URL of the JSON Schema describing ModelVersion, which is
+// kept in the schema directory of this repository
+const ModelSchema = "https://raw.githubusercontent.com/jsonnet-libs/docsonnet/master/schema/v1.json"
+
+// Document is the JSON interchange format of the docsonnet model: the root
+// Package, along with the version of the format
+type Document struct {
+	Schema  string `json:"$schema"`
+	Version int    `json:"version"`
+
+	Package
+}
+
+// Encode writes `pkg` to `w` in the current version of the interchange format
+func Encode(w io.Writer, pkg Package) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(Document{
+		Schema:  ModelSchema,
+		Version: ModelVersion,
+		Package: pkg,
+	})
+}
+
+// Decode reads a Package written by Encode, migrating older versions of the
+// interchange format. Documents without a version are considered version 0,
+// which is the format written before it was versioned.
+func Decode(data []byte) (*Package, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if v, ok := doc["version"]; ok {
+		f, ok := v.(float64)
+		if !ok || f != float64(int(f)) || f < 0 {
+			return nil, fmt.Errorf("version must be a non-negative integer, got %v", v)
+		}
+		version = int(f)
+	}
+	if version > ModelVersion {
+		return nil, fmt.Errorf("unsupported model version %d, this docsonnet supports up to version %d", version, ModelVersion)
+	}
+
+	for v := version; v < ModelVersion; v++ {
+		if err := migrations[v](doc); err != nil {
+			return nil, fmt.Errorf("migrating from version %d to %d: %w", v, v+1, err)
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var pkg Package
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// migrations upgrade a decoded document from the version of their index to the
+// next one
+var migrations = []func(doc map[string]interface{}) error{
+	migrateV0,
+}
+
+// migrateV0 migrates to version 1, which names each field. Before, names were
+// only known from the keys.
+func migrateV0(pkg map[string]interface{}) error {
+	if err := migrateV0Fields(pkg["api"]); err != nil {
+		return err
+	}
+
+	subs, _ := pkg["sub"].(map[string]interface{})
+	for name, s := range subs {
+		sub, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("sub package '%s' must be an object", name)
+		}
+		if err := migrateV0(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateV0Fields(i interface{}) error {
+	fields, _ := i.(map[string]interface{})
+	for name, f := range fields {
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("field '%s' must be an object", name)
+		}
+
+		for _, kind := range []string{"function", "object", "value"} {
+			v, ok := field[kind].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v["name"] = name
+
+			if err := migrateV0Fields(v["fields"]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package docsonnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	pkg := Package{
+		Name:   "example",
+		Import: "github.com/example/example",
+		API: Fields{
+			"new": {Function: &Function{
+				Name: "new",
+				Args: []Argument{{Name: "labels", Type: "string | array<string>"}},
+			}},
+			"config": {Object: &Object{
+				Name: "config",
+				Fields: Fields{
+					"replicas": {Value: &Value{Name: "replicas", Type: TypeNumber, Default: 1.0}},
+				},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, pkg))
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, ModelSchema, doc["$schema"])
+	assert.Equal(t, float64(ModelVersion), doc["version"])
+	assert.Contains(t, buf.String(), `"type": "string | array<string>"`)
+
+	got, err := Decode(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, pkg, *got)
+}
+
+func TestDecodeV0(t *testing.T) {
+	data := `{
+  "name": "example",
+  "import": "",
+  "help": "",
+  "api": {
+    "new": {"function": {"help": "", "args": [{"name": "labels", "type": "array", "default": null}]}}
+  },
+  "sub": {
+    "util": {"name": "util", "import": "", "help": "", "api": {
+      "config": {"object": {"help": "", "fields": {
+        "replicas": {"value": {"help": "", "type": "number", "default": 1}}
+      }}}
+    }}
+  }
+}`
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &doc))
+	require.NoError(t, migrateV0(doc))
+
+	fn := doc["api"].(map[string]interface{})["new"].(map[string]interface{})["function"].(map[string]interface{})
+	assert.Equal(t, "new", fn["name"])
+
+	pkg, err := Decode([]byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, "replicas", pkg.Sub["util"].API["config"].Object.Fields["replicas"].Value.Name)
+}
+
+func TestDecodeVersion(t *testing.T) {
+	_, err := Decode([]byte(fmt.Sprintf(`{"version": %d}`, ModelVersion+1)))
+	assert.Error(t, err)
+
+	_, err = Decode([]byte(`{"version": "1"}`))
+	assert.Error(t, err)
+
+	_, err = Decode([]byte(`{"version": -1}`))
+	assert.EqualError(t, err, "version must be a non-negative integer, got -1")
+}
+
+func TestModelSchema(t *testing.T) {
+	assert.Len(t, migrations, ModelVersion)
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "schema", fmt.Sprintf("v%d.json", ModelVersion)))
+	require.NoError(t, err)
+
+	var schema struct {
+		ID         string `json:"$id"`
+		Properties struct {
+			Version struct {
+				Const int `json:"const"`
+			} `json:"version"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, ModelSchema, schema.ID)
+	assert.Equal(t, ModelVersion, schema.Properties.Version.Const)
+}
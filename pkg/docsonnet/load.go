@@ -4,7 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"sort"
+	"strings"
 
 	"github.com/google/go-jsonnet"
 	"github.com/markbates/pkger"
@@ -12,17 +13,82 @@ import (
 
 type Opts struct {
 	JPath []string
+
+	// ExtVars and ExtCode set external variables, like `jsonnet --ext-str` and
+	// `--ext-code` do
+	ExtVars map[string]string
+	ExtCode map[string]string
+
+	// TLAVars and TLACode are passed as top level arguments to the library,
+	// like `jsonnet --tla-str` and `--tla-code` do. Not used by ExtractStatic.
+	TLAVars map[string]string
+	TLACode map[string]string
+
+	// NativeFunctions are made available to the library using std.native()
+	NativeFunctions []*jsonnet.NativeFunction
+
+	// JsonnetBundler resolves imports of dependencies declared in the
+	// jsonnetfile.json (or jsonnetfile.lock.json) found next to the library or
+	// in one of its parent directories. Its vendor directory is added to
+	// JPath, legacy import paths work without the symlinks jsonnet-bundler
+	// creates for them, and dependencies that are not installed are reported.
+	JsonnetBundler bool
+
+	// DocUtil selects the doc-util library that imports of
+	// `doc-util/main.libsonnet` and
+	// `github.com/jsonnet-libs/docsonnet/doc-util/main.libsonnet` resolve to:
+	// DocUtilBundled (the default), DocUtilVendored, or the path to a doc-util
+	// directory or its main.libsonnet.
+	DocUtil string
+
+	// ImportCache is used to read imported files. Share one between calls to
+	// Load to read each file only once. A new one is used if nil.
+	ImportCache *ImportCache
+
+	// Static makes Load use ExtractStatic instead of Extract
+	Static bool
+
+	// Diagnostics receives problems found while loading, that did not
+	// prevent it from succeeding. Discarded if nil.
+	Diagnostics *Diagnostics
+
+	// vm is reused by newVM if set, see LoadAll
+	vm *sharedVM
+}
+
+// sharedVM is a Jsonnet VM that loads one library after another, parsing the
+// files they import only once. Its importer is reconfigured for each library.
+// All libraries must be loaded using the same Opts, besides Diagnostics, so
+// the external variables and native functions of the VM stay the same.
+type sharedVM struct {
+	vm       *jsonnet.VM
+	importer *importer
+}
+
+func newSharedVM() *sharedVM {
+	s := &sharedVM{vm: jsonnet.MakeVM(), importer: &importer{}}
+	s.vm.Importer(s.importer)
+	return s
 }
 
 // Load extracts and transforms the docsonnet data in `filename`, returning the
 // top level docsonnet package.
 func Load(filename string, opts Opts) (*Package, error) {
-	data, err := Extract(filename, opts)
+	extract := Extract
+	if opts.Static {
+		extract = ExtractStatic
+	}
+
+	data, err := extract(filename, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return Transform([]byte(data))
+	pkg, diags, err := TransformWithDiagnostics(data)
+	if opts.Diagnostics != nil {
+		*opts.Diagnostics = append(*opts.Diagnostics, diags...)
+	}
+	return pkg, err
 }
 
 // Extract parses the Jsonnet file at `filename`, extracting all docsonnet related
@@ -40,16 +106,13 @@ func Extract(filename string, opts Opts) ([]byte, error) {
 		return nil, err
 	}
 
-	// setup Jsonnet vm
-	vm := jsonnet.MakeVM()
-	importer, err := newImporter(opts.JPath)
+	vm, err := newVM(filename, opts)
 	if err != nil {
 		return nil, err
 	}
-	vm.Importer(importer)
 
 	// invoke load.libsonnet
-	vm.ExtCode("main", fmt.Sprintf(`(import "%s")`, filename))
+	vm.ExtCode(mainExtVar, mainCode(filename, opts))
 
 	data, err := vm.EvaluateAnonymousSnippet("load.libsonnet", string(load))
 	if err != nil {
@@ -59,39 +122,152 @@ func Extract(filename string, opts Opts) ([]byte, error) {
 	return []byte(data), nil
 }
 
+// mainExtVar is the external variable load.libsonnet expects the library in
+const mainExtVar = "__docsonnet_main"
+
+// tlaExtVarPrefix is prepended to the name of string top level arguments, that
+// are passed to the library using external variables
+const tlaExtVarPrefix = "__docsonnet_tla_"
+
+// mainCode returns Jsonnet code that imports the library at `filename`. If top
+// level arguments are given in `opts`, the library is invoked with those, in
+// case it is a function. Like the jsonnet binary, they are ignored otherwise.
+func mainCode(filename string, opts Opts) string {
+	code := fmt.Sprintf(`(import "%s")`, filename)
+	if len(opts.TLAVars) == 0 && len(opts.TLACode) == 0 {
+		return code
+	}
+
+	var args []string
+	for _, k := range sortedKeys(opts.TLAVars) {
+		args = append(args, fmt.Sprintf(`%s=std.extVar("%s%s")`, k, tlaExtVarPrefix, k))
+	}
+	for _, k := range sortedKeys(opts.TLACode) {
+		args = append(args, fmt.Sprintf(`%s=(%s)`, k, opts.TLACode[k]))
+	}
+
+	// the local is prefixed, as the code of the arguments is evaluated in its
+	// scope
+	return fmt.Sprintf("local __docsonnet_lib = %s; if std.isFunction(__docsonnet_lib) then __docsonnet_lib(%s) else __docsonnet_lib", code, strings.Join(args, ", "))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newVM returns a Jsonnet VM set up according to `opts`, for evaluating the
+// library at `filename`
+func newVM(filename string, opts Opts) (*jsonnet.VM, error) {
+	importer, err := newImporter(filename, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := opts.vmFor(importer)
+
+	if b := importer.bundle; b != nil && opts.Diagnostics != nil {
+		for _, d := range b.missing() {
+			opts.Diagnostics.Warnf(RuleMissingDependency, "", "dependency %s from %s is not installed to %s. Run `jb install`", d, b.file, d.dir)
+		}
+	}
+
+	for k, v := range opts.ExtVars {
+		vm.ExtVar(k, v)
+	}
+	for k, v := range opts.ExtCode {
+		vm.ExtCode(k, v)
+	}
+	for k, v := range opts.TLAVars {
+		vm.ExtVar(tlaExtVarPrefix+k, v)
+	}
+	for _, f := range opts.NativeFunctions {
+		vm.NativeFunction(f)
+	}
+
+	return vm, nil
+}
+
+// vmFor returns opts.vm set up to use `imp`, or a new VM if unset
+func (o Opts) vmFor(imp *importer) *jsonnet.VM {
+	s := o.vm
+	if s == nil {
+		vm := jsonnet.MakeVM()
+		vm.Importer(imp)
+		return vm
+	}
+
+	// setting another importer would drop the files the VM parsed so far
+	*s.importer = *imp
+	return s.vm
+}
+
 // Transform converts the raw result of `Extract` to the actual docsonnet object
 // model `*docsonnet.Package`.
 func Transform(data []byte) (*Package, error) {
+	pkg, _, err := TransformWithDiagnostics(data)
+	return pkg, err
+}
+
+// TransformWithDiagnostics is like Transform, but also returns the problems
+// found in the docsonnet data that did not prevent it from being loaded, such
+// as unknown types.
+func TransformWithDiagnostics(data []byte) (*Package, Diagnostics, error) {
 	var d ds
 	if err := json.Unmarshal([]byte(data), &d); err != nil {
-		log.Fatalln(err)
+		return nil, nil, err
 	}
 
-	p := fastLoad(d)
-	return &p, nil
+	p, diags := fastLoad(d)
+	return &p, diags, nil
 }
 
 // importer wraps jsonnet.FileImporter, to statically provide load.libsonnet,
-// bundled with the binary
+// bundled with the binary, and to resolve jsonnet-bundler dependencies
 type importer struct {
-	fi   jsonnet.FileImporter
-	util jsonnet.Contents
+	cache  *ImportCache
+	jpaths []string
+	bundle *bundle
+
+	// docUtil is the Opts.DocUtil mode, or the path to a doc-util
+	docUtil string
+	util    jsonnet.Contents
 }
 
-func newImporter(paths []string) (*importer, error) {
-	file, err := pkger.Open("/doc-util/main.libsonnet")
-	if err != nil {
+// newImporter returns the importer for the library at `filename`
+func newImporter(filename string, opts Opts) (*importer, error) {
+	i := &importer{
+		cache:  opts.ImportCache,
+		jpaths: opts.JPath,
+	}
+
+	if i.cache == nil {
+		i.cache = NewImportCache()
+	}
+
+	var err error
+	if i.util, err = i.cache.bundledDocUtil(); err != nil {
 		return nil, err
 	}
-	load, err := io.ReadAll(file)
-	if err != nil {
+	if i.docUtil, err = docUtilFile(opts.DocUtil); err != nil {
 		return nil, err
 	}
 
-	return &importer{
-		fi:   jsonnet.FileImporter{JPaths: paths},
-		util: jsonnet.MakeContents(string(load)),
-	}, nil
+	if opts.JsonnetBundler {
+		b, err := findBundle(filename)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			i.useBundle(b)
+		}
+	}
+
+	return i, nil
 }
 
 var docUtilPaths = []string{
@@ -99,12 +275,61 @@ var docUtilPaths = []string{
 	"github.com/jsonnet-libs/docsonnet/doc-util/main.libsonnet",
 }
 
-func (i *importer) Import(importedFrom, importedPath string) (contents jsonnet.Contents, foundAt string, err error) {
+// bundledFoundAt is the location reported for the bundled doc-util
+const bundledFoundAt = "<internal>"
+
+func isDocUtil(importedPath string) bool {
 	for _, p := range docUtilPaths {
 		if importedPath == p {
-			return i.util, "<internal>", nil
+			return true
+		}
+	}
+	return false
+}
+
+func (i *importer) Import(importedFrom, importedPath string) (contents jsonnet.Contents, foundAt string, err error) {
+	if !isDocUtil(importedPath) {
+		return i.importFile(importedFrom, importedPath)
+	}
+
+	switch i.docUtil {
+	case "", DocUtilBundled:
+		return i.util, bundledFoundAt, nil
+	case DocUtilVendored:
+		if contents, foundAt, err := i.importFile(importedFrom, importedPath); err == nil {
+			return contents, foundAt, nil
 		}
+		return i.util, bundledFoundAt, nil
+	default:
+		return i.cache.find(nil, "", i.docUtil)
 	}
+}
+
+// importFile imports from the JPath, falling back to the dependencies of the
+// jsonnet-bundler project, if any
+func (i *importer) importFile(importedFrom, importedPath string) (contents jsonnet.Contents, foundAt string, err error) {
+	contents, foundAt, err = i.cache.find(i.jpaths, importedFrom, importedPath)
+	if err == nil || i.bundle == nil {
+		return contents, foundAt, err
+	}
+
+	file, dep := i.bundle.resolve(importedPath)
+	if dep == nil {
+		return contents, foundAt, err
+	}
+	if !dep.installed() {
+		return contents, foundAt, fmt.Errorf("importing '%s': dependency %s is not installed to %s. Run `jb install` in %s", importedPath, dep, dep.dir, i.bundle.root)
+	}
+
+	if contents, foundAt, ferr := i.cache.find(nil, "", file); ferr == nil {
+		return contents, foundAt, nil
+	}
+	return contents, foundAt, err
+}
 
-	return i.fi.Import(importedFrom, importedPath)
+// useBundle resolves imports using the dependencies of `b`. Its vendor
+// directory is searched with the lowest precedence.
+func (i *importer) useBundle(b *bundle) {
+	i.bundle = b
+	i.jpaths = append([]string{b.vendor}, i.jpaths...)
 }
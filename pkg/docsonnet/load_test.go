@@ -0,0 +1,114 @@
+package docsonnet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadVars(t *testing.T) {
+	upper := &jsonnet.NativeFunction{
+		Name:   "upper",
+		Params: ast.Identifiers{"s"},
+		Func: func(args []interface{}) (interface{}, error) {
+			return strings.ToUpper(args[0].(string)), nil
+		},
+	}
+
+	pkg, err := Load("testdata/vars/main.libsonnet", Opts{
+		ExtVars:         map[string]string{"cluster": "prod"},
+		TLAVars:         map[string]string{"team": "infra"},
+		NativeFunctions: []*jsonnet.NativeFunction{upper},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "INFRA", pkg.Name)
+	assert.True(t, strings.HasPrefix(pkg.Help, "deploys to prod"))
+
+	// like the jsonnet binary, libraries that are no function ignore them
+	pkg, err = Load("testdata/bundler/main.libsonnet", Opts{
+		JsonnetBundler: true,
+		TLAVars:        map[string]string{"team": "infra"},
+		TLACode:        map[string]string{"replicas": "3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bundled", pkg.Name)
+}
+
+func TestLoadJsonnetBundler(t *testing.T) {
+	var diags Diagnostics
+	pkg, err := Load("testdata/bundler/main.libsonnet", Opts{
+		JsonnetBundler: true,
+		Diagnostics:    &diags,
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pkg.Help, "hello vendor"))
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, RuleMissingDependency, diags[0].Rule)
+	assert.Contains(t, diags[0].Message, "github.com/example/missing@v1.0.0")
+
+	_, err = Extract("testdata/bundler/missing.libsonnet", Opts{JsonnetBundler: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency github.com/example/missing@v1.0.0 is not installed")
+
+	_, err = Extract("testdata/bundler/main.libsonnet", Opts{})
+	assert.Error(t, err, "legacy imports require JsonnetBundler")
+}
+
+func TestRemotePath(t *testing.T) {
+	for remote, want := range map[string]string{
+		"https://github.com/grafana/jsonnet-libs.git": "github.com/grafana/jsonnet-libs",
+		"https://github.com/grafana/jsonnet-libs":     "github.com/grafana/jsonnet-libs",
+		"git@github.com:grafana/jsonnet-libs.git":     "github.com/grafana/jsonnet-libs",
+		"ssh://git@github.com/grafana/jsonnet-libs":   "github.com/grafana/jsonnet-libs",
+	} {
+		assert.Equal(t, want, remotePath(remote), remote)
+	}
+}
+
+func TestDetectDocUtil(t *testing.T) {
+	du, err := DetectDocUtil("testdata/docutil/main.libsonnet", Opts{JsonnetBundler: true})
+	require.NoError(t, err)
+	require.NotNil(t, du)
+
+	assert.Equal(t, "v0.0.1", du.Version)
+	assert.Equal(t, DocUtilFeatures{}, du.Features)
+	assert.Len(t, du.Unsupported(), 4)
+
+	du, err = DetectDocUtil("testdata/vars/main.libsonnet", Opts{})
+	require.NoError(t, err)
+	assert.Nil(t, du)
+
+	du, err = DetectDocUtil("testdata/vars/main.libsonnet", Opts{JPath: []string{"../.."}})
+	require.NoError(t, err)
+	require.NotNil(t, du)
+	assert.Equal(t, DocUtilFeatures{Enums: true, Schema: true, Return: true, Union: true}, du.Features)
+
+	// a doc-util given by path is described instead of the vendored one
+	du, err = DetectDocUtil("testdata/docutil/main.libsonnet", Opts{JsonnetBundler: true, DocUtil: "../../doc-util"})
+	require.NoError(t, err)
+	require.NotNil(t, du)
+	assert.Equal(t, "", du.Version)
+	assert.Equal(t, DocUtilFeatures{Enums: true, Schema: true, Return: true, Union: true}, du.Features)
+}
+
+func TestLoadDocUtil(t *testing.T) {
+	for _, docUtil := range []string{DocUtilBundled, DocUtilVendored, "testdata/docutil/vendor/github.com/jsonnet-libs/docsonnet/doc-util"} {
+		pkg, err := Load("testdata/docutil/main.libsonnet", Opts{JsonnetBundler: true, DocUtil: docUtil})
+		require.NoError(t, err, docUtil)
+
+		arg := pkg.API["new"].Function.Args[0]
+		assert.Equal(t, Type(TypeBool), arg.Type, docUtil)
+
+		// only the bundled doc-util adds install instructions
+		assert.Equal(t, docUtil == DocUtilBundled, strings.Contains(pkg.Help, "jb install"), docUtil)
+	}
+
+	_, err := Load("testdata/docutil/main.libsonnet", Opts{DocUtil: "testdata/missing"})
+	assert.Error(t, err)
+}
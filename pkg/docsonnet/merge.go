@@ -0,0 +1,36 @@
+package docsonnet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Merge combines independently loaded packages into a single tree, as sub
+// packages of a synthetic root package named `name`. Rendering the root
+// produces a landing page listing all of them. Fails if packages share a
+// name, as they would overwrite each other.
+func Merge(name, help string, pkgs ...Package) (*Package, error) {
+	root := Package{
+		Name: name,
+		Help: help,
+		Sub:  make(map[string]Package, len(pkgs)),
+	}
+
+	var collisions []string
+	for _, p := range pkgs {
+		if p.Name == "" {
+			return nil, fmt.Errorf("package '%s' has no name", p.Import)
+		}
+		if other, ok := root.Sub[p.Name]; ok {
+			collisions = append(collisions, fmt.Sprintf("'%s' (%s, %s)", p.Name, other.Import, p.Import))
+			continue
+		}
+		root.Sub[p.Name] = p
+	}
+
+	if len(collisions) > 0 {
+		return nil, errors.New("multiple packages are named " + strings.Join(collisions, ", "))
+	}
+	return &root, nil
+}
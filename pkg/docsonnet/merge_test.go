@@ -0,0 +1,27 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	a := Package{Name: "a", Import: "github.com/example/a"}
+	b := Package{Name: "b", Import: "github.com/example/b"}
+
+	root, err := Merge("libs", "all libraries", a, b)
+	require.NoError(t, err)
+	assert.Equal(t, &Package{
+		Name: "libs",
+		Help: "all libraries",
+		Sub:  map[string]Package{"a": a, "b": b},
+	}, root)
+
+	_, err = Merge("libs", "", a, b, Package{Name: "a", Import: "github.com/other/a"})
+	assert.EqualError(t, err, "multiple packages are named 'a' (github.com/example/a, github.com/other/a)")
+
+	_, err = Merge("libs", "", Package{Import: "github.com/example/anonymous"})
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package docsonnet
 
+import "strings"
+
 // Package represents a Jsonnet package, having an API (list of Fields) and
 // perhaps subpackages
 type Package struct {
@@ -13,7 +15,7 @@ type Package struct {
 
 // Object represents a Jsonnet object, a list of key-value fields
 type Object struct {
-	Name string `json:"-"`
+	Name string `json:"name"`
 	Help string `json:"help"`
 
 	// children
@@ -23,10 +25,17 @@ type Object struct {
 // Function represents a Jsonnet function, a named construct that takes
 // arguments
 type Function struct {
-	Name string `json:"-"`
+	Name string `json:"name"`
 	Help string `json:"help"`
 
-	Args []Argument `json:"args,omitempty"`
+	Args   []Argument `json:"args,omitempty"`
+	Return *Return    `json:"return,omitempty"`
+}
+
+// Return describes the value returned by a function
+type Return struct {
+	Type Type   `json:"type"`
+	Help string `json:"help,omitempty"`
 }
 
 // Argument is a function argument, optionally also having a default value
@@ -34,20 +43,52 @@ type Argument struct {
 	Type    Type        `json:"type"`
 	Name    string      `json:"name"`
 	Default interface{} `json:"default"`
+
+	// Enums are the values the argument may take, if restricted
+	Enums []interface{} `json:"enums,omitempty"`
+	// Schema is the JSON schema describing the argument, if given using
+	// `d.argument.fromSchema`
+	Schema map[string]interface{} `json:"schema,omitempty"`
 }
 
 // Value is a value of any other type than the special Object and Function types
 type Value struct {
-	Name string `json:"-"`
+	Name string `json:"name"`
 	Help string `json:"help"`
 
 	Type    Type        `json:"type"`
 	Default interface{} `json:"default"`
 }
 
-// Type is a Jsonnet type
-type Type string
+// Runtime holds information about a documented field, that is not part of its
+// docstring but observed on the field itself
+type Runtime struct {
+	Visibility Visibility `json:"visibility"`
+	// Type is the Jsonnet type of the fields value, as returned by std.type()
+	Type Type `json:"type"`
+}
+
+// Location in a Jsonnet source file
+type Location struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Visibility of an object field
+type Visibility string
 
+const (
+	// VisibilityVisible fields use `:` and are included in the output
+	VisibilityVisible Visibility = "visible"
+	// VisibilityHidden fields use `::`
+	VisibilityHidden Visibility = "hidden"
+	// VisibilityForced fields use `:::`. This can't be told apart from
+	// VisibilityVisible during evaluation, so only static extraction reports it.
+	VisibilityForced Visibility = "forced"
+)
+
+// Names of the simple Jsonnet types
 const (
 	TypeString = "string"
 	TypeNumber = "number"
@@ -56,4 +97,39 @@ const (
 	TypeArray  = "array"
 	TypeAny    = "any"
 	TypeFunc   = "function"
+	TypeNull   = "null"
 )
+
+// Lookup returns the field at the dotted `path`, or the sub package if `path`
+// refers to one. Fields of sub packages are prefixed with the package name,
+// like in `sub.new`. Returns nil for both if nothing was found.
+func (p *Package) Lookup(path string) (*Field, *Package) {
+	if path == "" {
+		return nil, p
+	}
+
+	name, rest, _ := strings.Cut(path, ".")
+	if f, ok := p.API[name]; ok {
+		return f.lookup(rest), nil
+	}
+	if sub, ok := p.Sub[name]; ok {
+		return sub.Lookup(rest)
+	}
+	return nil, nil
+}
+
+func (f Field) lookup(path string) *Field {
+	if path == "" {
+		return &f
+	}
+	if f.Object == nil {
+		return nil
+	}
+
+	name, rest, _ := strings.Cut(path, ".")
+	child, ok := f.Object.Fields[name]
+	if !ok {
+		return nil
+	}
+	return child.lookup(rest)
+}
@@ -0,0 +1,34 @@
+package docsonnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	pkg := &Package{
+		Name: "lib",
+		API: Fields{
+			"nested": {Object: &Object{Name: "nested", Fields: Fields{
+				"withName": {Function: &Function{Name: "withName"}},
+			}}},
+		},
+		Sub: map[string]Package{
+			"sub": {Name: "sub", API: Fields{"new": {Function: &Function{Name: "new"}}}},
+		},
+	}
+
+	f, _ := pkg.Lookup("nested.withName")
+	assert.Equal(t, "withName", f.Function.Name)
+
+	f, _ = pkg.Lookup("sub.new")
+	assert.Equal(t, "new", f.Function.Name)
+
+	_, sub := pkg.Lookup("sub")
+	assert.Equal(t, "sub", sub.Name)
+
+	f, sub = pkg.Lookup("nested.missing")
+	assert.Nil(t, f)
+	assert.Nil(t, sub)
+}
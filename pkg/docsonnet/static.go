@@ -0,0 +1,380 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+	"github.com/google/go-jsonnet/toolutils"
+)
+
+// ExtractStatic is an alternative to Extract, that does not evaluate the
+// library at `filename`. Instead, its source is parsed and docstrings are
+// collected from object literals (`'#name': d.fn(...)`), following imports,
+// locals and object merges. Only the docstring expressions themselves are
+// evaluated.
+//
+// This works for libraries that require ext vars, top level arguments or
+// native functions to evaluate, and is a lot faster for large libraries. In
+// return, fields that only come into existence during evaluation (e.g. from
+// function calls or comprehensions) are not found, and docstrings that refer to
+// `self` can't be evaluated. Such docstrings are reported to opts.Diagnostics.
+//
+// The output has the same format as the one of Extract, but also includes the
+// source location of each docstring.
+func ExtractStatic(filename string, opts Opts) ([]byte, error) {
+	vm, err := newVM(filename, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	node, foundAt, err := vm.ImportAST("", filename)
+	if err != nil {
+		return nil, err
+	}
+
+	e := staticExtractor{
+		vm:       vm,
+		visiting: make(map[ast.Node]bool),
+		files:    map[string]bool{foundAt: true},
+	}
+	out := e.walk(node, nil, "")
+	if out == nil {
+		out = make(map[string]interface{})
+	}
+
+	if opts.Diagnostics != nil {
+		*opts.Diagnostics = append(*opts.Diagnostics, e.diags...)
+	}
+
+	return marshalJSON(out)
+}
+
+// staticExtractor walks a desugared Jsonnet AST, collecting docstrings
+type staticExtractor struct {
+	vm    *jsonnet.VM
+	diags Diagnostics
+
+	// visiting guards against cycles through locals
+	visiting map[ast.Node]bool
+	// files that are currently being walked, to guard against import cycles
+	files map[string]bool
+}
+
+// scope holds the local variables visible at some point of the AST
+type scope struct {
+	parent *scope
+	binds  ast.LocalBinds
+}
+
+func (s *scope) with(binds ast.LocalBinds) *scope {
+	if len(binds) == 0 {
+		return s
+	}
+	return &scope{parent: s, binds: binds}
+}
+
+// lookup returns the bind of variable `id`, along with the scope it is
+// defined in
+func (s *scope) lookup(id ast.Identifier) (*ast.LocalBind, *scope) {
+	for c := s; c != nil; c = c.parent {
+		for i := range c.binds {
+			if c.binds[i].Variable == id {
+				return &c.binds[i], c
+			}
+		}
+	}
+	return nil, nil
+}
+
+// walk returns the docstrings of the object `node` evaluates to, in the format
+// of load.libsonnet. Returns nil if no docstrings were found. `path` is the
+// dotted path of the object, used for diagnostics.
+func (e *staticExtractor) walk(node ast.Node, env *scope, path string) map[string]interface{} {
+	if e.visiting[node] {
+		return nil
+	}
+	e.visiting[node] = true
+	defer delete(e.visiting, node)
+
+	switch n := node.(type) {
+	case *ast.DesugaredObject:
+		return e.object(n, env, path)
+	case *ast.Binary:
+		if n.Op != ast.BopPlus {
+			return nil
+		}
+		return mergeRaw(e.walk(n.Left, env, path), e.walk(n.Right, env, path))
+	case *ast.Local:
+		return e.walk(n.Body, env.with(n.Binds), path)
+	case *ast.Var:
+		bind, defined := env.lookup(n.Id)
+		if bind == nil {
+			return nil
+		}
+		return e.walk(bind.Body, defined, path)
+	case *ast.Index:
+		idx, ok := n.Index.(*ast.LiteralString)
+		if !ok {
+			return nil
+		}
+		sub, _ := e.walk(n.Target, env, path)[idx.Value].(map[string]interface{})
+		return sub
+	case *ast.Import:
+		return e.importFile(n, path)
+	}
+
+	return nil
+}
+
+func (e *staticExtractor) importFile(n *ast.Import, path string) map[string]interface{} {
+	node, foundAt, err := e.vm.ImportAST(n.Loc().FileName, n.File.Value)
+	if err != nil || e.files[foundAt] {
+		return nil
+	}
+
+	e.files[foundAt] = true
+	defer delete(e.files, foundAt)
+
+	return e.walk(node, nil, path)
+}
+
+func (e *staticExtractor) object(obj *ast.DesugaredObject, env *scope, path string) map[string]interface{} {
+	env = env.with(obj.Locals)
+
+	fields := make(map[string]ast.DesugaredObjectField)
+	for _, f := range obj.Fields {
+		if name, ok := f.Name.(*ast.LiteralString); ok {
+			fields[name.Value] = f
+		}
+	}
+
+	out := make(map[string]interface{})
+	ignored := make(map[string]bool)
+	for name, f := range fields {
+		if !strings.HasPrefix(name, "#") {
+			if nested := e.walk(f.Body, env, joinPath(path, name)); len(nested) > 0 {
+				out[name] = mergeRaw(asRaw(out[name]), nested)
+			}
+			continue
+		}
+
+		doc, ok := e.docstring(joinPath(path, strings.TrimPrefix(name, "#")), f, env)
+		if !ok {
+			continue
+		}
+		if doc == "ignore" {
+			ignored[strings.TrimPrefix(name, "#")] = true
+			continue
+		}
+		msi, ok := doc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if field, ok := fields[name[1:]]; ok && name != "#" {
+			msi["runtime"] = staticRuntime(field)
+		}
+		msi["location"] = map[string]interface{}{
+			"file":   f.LocRange.FileName,
+			"line":   f.LocRange.Begin.Line,
+			"column": f.LocRange.Begin.Column,
+		}
+		out[name] = msi
+	}
+
+	for name := range ignored {
+		delete(out, name)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// docstring evaluates the docstring field `f` of the field at `path`, with the
+// locals of `env` in scope.
+func (e *staticExtractor) docstring(path string, f ast.DesugaredObjectField, env *scope) (interface{}, bool) {
+	if usesSelf(f.Body) {
+		e.diags.Warnf(RuleStaticEval, path, "docstring refers to self or super, which can't be evaluated statically")
+		return nil, false
+	}
+
+	node, available := withScope(f.Body, env)
+	for _, v := range f.Body.FreeVariables() {
+		if !available[v] {
+			e.diags.Warnf(RuleStaticEval, path, "docstring refers to local '%s', which can't be evaluated statically", v)
+			return nil, false
+		}
+	}
+
+	data, err := e.vm.Evaluate(node)
+	if err != nil {
+		e.diags.Warnf(RuleStaticEval, path, "evaluating docstring: %s", strings.TrimSpace(err.Error()))
+		return nil, false
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		e.diags.Warnf(RuleStaticEval, path, "evaluating docstring: %s", err)
+		return nil, false
+	}
+
+	return doc, true
+}
+
+// withScope wraps `body` in local expressions binding the variables of `env`,
+// so it can be evaluated on its own. Locals that refer to `self`, or to other
+// locals that do, can't be evaluated outside of their object and are omitted.
+// Returns the wrapped node and the variables available to `body`.
+func withScope(body ast.Node, env *scope) (ast.Node, map[ast.Identifier]bool) {
+	var scopes []*scope
+	for s := env; s != nil; s = s.parent {
+		scopes = append([]*scope{s}, scopes...)
+	}
+
+	available := map[ast.Identifier]bool{"std": true, "$std": true}
+	var locals []*ast.Local
+	var bound ast.Identifiers
+	for _, s := range scopes {
+		binds := usableBinds(s.binds, available)
+		for _, b := range binds {
+			available[b.Variable] = true
+		}
+		if len(binds) == 0 {
+			continue
+		}
+
+		l := &ast.Local{Binds: binds}
+		// variables of outer scopes must be captured explicitly
+		l.SetFreeVariables(append(ast.Identifiers(nil), bound...))
+		for _, b := range binds {
+			bound = append(bound, b.Variable)
+		}
+		locals = append(locals, l)
+	}
+
+	node := body
+	for i := len(locals) - 1; i >= 0; i-- {
+		locals[i].Body = node
+		node = locals[i]
+	}
+	return node, available
+}
+
+// usableBinds returns those of `binds` that can be evaluated outside of their
+// object, given the variables `available` from outer scopes. Binds of the same
+// local expression may refer to each other.
+func usableBinds(binds ast.LocalBinds, available map[ast.Identifier]bool) ast.LocalBinds {
+	usable := make(map[ast.Identifier]bool)
+	for _, b := range binds {
+		usable[b.Variable] = !usesSelf(b.Body)
+	}
+
+	// drop binds that refer to unusable ones, until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, b := range binds {
+			if !usable[b.Variable] {
+				continue
+			}
+			for _, v := range b.Body.FreeVariables() {
+				ok, local := usable[v]
+				if (local && !ok) || (!local && !available[v]) {
+					usable[b.Variable] = false
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var out ast.LocalBinds
+	for _, b := range binds {
+		if usable[b.Variable] {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// usesSelf reports whether `node` refers to the object it is part of, using
+// `self`, `super` or `$`
+func usesSelf(node ast.Node) bool {
+	switch n := node.(type) {
+	case nil:
+		return false
+	case *ast.Self, *ast.SuperIndex, *ast.InSuper:
+		return true
+	case *ast.Var:
+		return n.Id == "$"
+	case *ast.DesugaredObject, *ast.ObjectComp:
+		// self refers to the nested object in here
+		return false
+	}
+
+	for _, c := range toolutils.Children(node) {
+		if usesSelf(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// staticRuntime describes the documented field `f`, as far as this is possible
+// from the AST alone
+func staticRuntime(f ast.DesugaredObjectField) map[string]interface{} {
+	rt := make(map[string]interface{})
+
+	switch f.Hide {
+	case ast.ObjectFieldHidden:
+		rt["visibility"] = string(VisibilityHidden)
+	case ast.ObjectFieldVisible:
+		rt["visibility"] = string(VisibilityForced)
+	default:
+		rt["visibility"] = string(VisibilityVisible)
+	}
+
+	switch f.Body.(type) {
+	case *ast.Function:
+		rt["type"] = TypeFunc
+	case *ast.DesugaredObject, *ast.ObjectComp:
+		rt["type"] = TypeObject
+	case *ast.Array, *ast.ArrayComp:
+		rt["type"] = TypeArray
+	case *ast.LiteralString:
+		rt["type"] = TypeString
+	case *ast.LiteralNumber:
+		rt["type"] = TypeNumber
+	case *ast.LiteralBoolean:
+		rt["type"] = TypeBool
+	case *ast.LiteralNull:
+		rt["type"] = TypeNull
+	}
+
+	return rt
+}
+
+func asRaw(i interface{}) map[string]interface{} {
+	msi, _ := i.(map[string]interface{})
+	return msi
+}
+
+// mergeRaw deeply merges the extracted docstrings `b` into `a`. Docstrings of
+// `b` take precedence.
+func mergeRaw(a, b map[string]interface{}) map[string]interface{} {
+	if a == nil {
+		return b
+	}
+
+	for k, v := range b {
+		if strings.HasPrefix(k, "#") {
+			a[k] = v
+			continue
+		}
+		a[k] = mergeRaw(asRaw(a[k]), asRaw(v))
+	}
+	return a
+}
@@ -0,0 +1,45 @@
+package docsonnet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractStatic(t *testing.T) {
+	var diags Diagnostics
+	data, err := ExtractStatic("testdata/static/main.libsonnet", Opts{Diagnostics: &diags})
+	require.NoError(t, err)
+
+	pkg, _, err := TransformWithDiagnostics(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "app", pkg.Name)
+
+	// evaluating new() would fail, as the ext var is not set
+	require.NotNil(t, pkg.API["new"].Function)
+	assert.Equal(t, "new creates an app", pkg.API["new"].Function.Help)
+	assert.Equal(t, &Runtime{Visibility: VisibilityHidden, Type: TypeFunc}, pkg.API["new"].Runtime)
+	assert.Equal(t, &Location{File: "testdata/static/main.libsonnet", Line: 9, Column: 3}, pkg.API["new"].Location)
+
+	assert.Equal(t, VisibilityForced, pkg.API["replicas"].Runtime.Visibility)
+
+	// fields from imports and mixins
+	container := pkg.API["container"].Object
+	require.NotNil(t, container)
+	assert.Equal(t, "withImage sets the image", container.Fields["withImage"].Function.Help)
+	assert.Equal(t, "ports of the container", container.Fields["ports"].Object.Help)
+	assert.Contains(t, container.Fields["ports"].Object.Fields, "withPort")
+	assert.Equal(t, "container.libsonnet", filepath.Base(container.Fields["withImage"].Location.File))
+
+	// docstrings referring to self are reported
+	assert.NotContains(t, pkg.API, "help")
+	assert.Equal(t, Diagnostics{{
+		Severity: SeverityWarning,
+		Rule:     RuleStaticEval,
+		Path:     "help",
+		Message:  "docstring refers to local 'this', which can't be evaluated statically",
+	}}, diags)
+}
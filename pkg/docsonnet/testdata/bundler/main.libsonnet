@@ -0,0 +1,11 @@
+local d = import 'doc-util/main.libsonnet';
+// legacy import path, without the symlink jsonnet-bundler would create
+local util = import 'util/main.libsonnet';
+
+{
+  '#': d.pkg(
+    name='bundled',
+    url='github.com/example/bundled',
+    help=util.greet('vendor'),
+  ),
+}
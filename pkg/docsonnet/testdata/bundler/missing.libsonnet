@@ -0,0 +1,6 @@
+local d = import 'doc-util/main.libsonnet';
+local missing = import 'missing/main.libsonnet';
+
+{
+  '#': d.pkg(name='missing', url='', help=missing.help),
+}
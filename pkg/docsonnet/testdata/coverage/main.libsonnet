@@ -0,0 +1,23 @@
+local d = import 'doc-util/main.libsonnet';
+
+{
+  '#': d.pkg(name='coverage', url='github.com/example/coverage', help=''),
+
+  '#new': d.fn('new creates a new thing', [d.arg('name', d.T.string)]),
+  new(name):: { name: name },
+
+  withName(name):: { name: name },
+
+  '#internal': 'ignore',
+  internal:: { helper(): null },
+
+  // not documented at all, so not counted
+  util:: { a: 1, b: 2 },
+
+  '#nested': d.obj('nested things'),
+  nested: {
+    '#withReplicas': d.fn('sets replicas', [d.arg('n', d.T.number)]),
+    withReplicas(n):: { replicas: n },
+    withImage(image):: { image: image },
+  },
+}
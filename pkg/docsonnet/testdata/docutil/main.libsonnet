@@ -0,0 +1,12 @@
+local d = import 'doc-util/main.libsonnet';
+
+{
+  '#': d.pkg(
+    name='old',
+    url='github.com/example/old',
+    help='written against an early doc-util',
+  ),
+
+  '#new': d.fn('new creates a new thing', [d.arg('enabled', d.T.bool)]),
+  new(enabled):: {},
+}
@@ -0,0 +1,14 @@
+local d = import 'doc-util/main.libsonnet';
+local withHelp(help) = d.obj(help);
+
+{
+  '#withImage': d.fn('withImage sets the image', [d.arg('image', d.T.string)]),
+  withImage(image):: { image: image },
+}
++ {
+  '#ports': withHelp('ports of the container'),
+  ports: {
+    '#withPort': d.fn('withPort adds a port', [d.arg('port', d.T.number)]),
+    withPort(port):: { ports+: [port] },
+  },
+}
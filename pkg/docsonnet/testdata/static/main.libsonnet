@@ -0,0 +1,19 @@
+local d = import 'doc-util/main.libsonnet';
+local container = import 'container.libsonnet';
+
+{
+  local this = self,
+
+  '#': d.pkg(name='app', url='github.com/example/app', help='app deploys apps'),
+
+  '#new': d.fn('new creates an app', [d.arg('name', d.T.string)]),
+  new(name):: { name: std.extVar('cluster') + name },
+
+  '#replicas': d.val(d.T.number, 'replica count', default=1),
+  replicas::: 1,
+
+  '#help':: d.fn(this.helpText),
+  help():: {},
+
+  container: container,
+}
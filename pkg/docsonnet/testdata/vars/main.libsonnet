@@ -0,0 +1,9 @@
+local d = import 'doc-util/main.libsonnet';
+
+function(team) {
+  '#': d.pkg(
+    name=std.native('upper')(team),
+    url='github.com/example/vars',
+    help='deploys to %s' % std.extVar('cluster'),
+  ),
+}
@@ -0,0 +1,351 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Type is a Jsonnet type, written as a type expression. Besides the simple
+// types (TypeString, TypeNumber, ...), it can describe arrays and objects whose
+// elements are of a certain type (`array<string>`, `object<number>`) and
+// unions of other types (`string | array<string>`). Use Expr to inspect it.
+type Type string
+
+// Expr returns the parsed type expression of t. Types that are no valid
+// expression are returned as a simple type of that name, for compatibility
+// with free-form types.
+func (t Type) Expr() TypeExpr {
+	e, err := ParseType(string(t))
+	if err != nil {
+		return SimpleType(string(t))
+	}
+	return e
+}
+
+// Valid reports whether t solely consists of the types listed in the Type*
+// constants, or their aliases, see TypeExpr.Normalize
+func (t Type) Valid() bool {
+	return t.Expr().Normalize().Valid()
+}
+
+// UnmarshalJSON accepts a type expression string, or an array of those for
+// unions. Strings are kept as-is, even if they are no valid type expression.
+func (t *Type) UnmarshalJSON(data []byte) error {
+	var i interface{}
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+
+	switch v := i.(type) {
+	case nil:
+		*t = ""
+	case string:
+		*t = Type(v)
+	case []interface{}:
+		got, err := typeFrom(v)
+		if err != nil {
+			return err
+		}
+		*t = Type(got.String())
+	default:
+		return fmt.Errorf("type must be a string or an array of types, got %T", i)
+	}
+	return nil
+}
+
+// TypeExpr is a parsed type expression, see Type and ParseType
+type TypeExpr struct {
+	// Name of a simple type. For collections this is TypeArray or TypeObject,
+	// for unions it is empty.
+	Name string
+	// Elem is the type of the elements of a collection, if known
+	Elem *TypeExpr
+	// Union holds the alternatives of a union type
+	Union []TypeExpr
+}
+
+// SimpleType returns the type expression of the type called `name`
+func SimpleType(name string) TypeExpr {
+	return TypeExpr{Name: name}
+}
+
+// ArrayOf returns the type of an array holding elements of type `t`
+func ArrayOf(t TypeExpr) TypeExpr {
+	return TypeExpr{Name: TypeArray, Elem: &t}
+}
+
+// ObjectOf returns the type of an object with fields of type `t`
+func ObjectOf(t TypeExpr) TypeExpr {
+	return TypeExpr{Name: TypeObject, Elem: &t}
+}
+
+// UnionOf returns a type accepting any of `types`. Nested unions are
+// flattened and a union of a single type is that type.
+func UnionOf(types ...TypeExpr) TypeExpr {
+	var u []TypeExpr
+	for _, t := range types {
+		if t.IsUnion() {
+			u = append(u, t.Union...)
+			continue
+		}
+		u = append(u, t)
+	}
+
+	if len(u) == 1 {
+		return u[0]
+	}
+	return TypeExpr{Union: u}
+}
+
+// IsZero reports whether no type information is present
+func (t TypeExpr) IsZero() bool {
+	return t.Name == "" && t.Elem == nil && len(t.Union) == 0
+}
+
+// IsUnion reports whether t is a union of multiple types
+func (t TypeExpr) IsUnion() bool {
+	return len(t.Union) > 0
+}
+
+// Type returns the type expression t as a Type
+func (t TypeExpr) Type() Type {
+	return Type(t.String())
+}
+
+// knownTypes are the canonical names of the simple types
+var knownTypes = map[string]bool{
+	TypeString: true,
+	TypeNumber: true,
+	TypeBool:   true,
+	TypeObject: true,
+	TypeArray:  true,
+	TypeAny:    true,
+	TypeFunc:   true,
+	TypeNull:   true,
+}
+
+// typeAliases maps alternative spellings to the canonical type names.
+// doc-util for example used `bool` for booleans for a long time.
+var typeAliases = map[string]string{
+	"bool":    TypeBool,
+	"func":    TypeFunc,
+	"int":     TypeNumber,
+	"integer": TypeNumber,
+	"nil":     TypeNull,
+}
+
+// Normalize returns t with all known type names converted to their canonical
+// spelling (e.g. `bool` to `boolean`). Unknown names are left untouched.
+func (t TypeExpr) Normalize() TypeExpr {
+	switch {
+	case t.IsUnion():
+		u := make([]TypeExpr, len(t.Union))
+		for i, m := range t.Union {
+			u[i] = m.Normalize()
+		}
+		return UnionOf(u...)
+	case t.Elem != nil:
+		elem := t.Elem.Normalize()
+		return TypeExpr{Name: t.Name, Elem: &elem}
+	}
+
+	name := strings.ToLower(t.Name)
+	if knownTypes[name] {
+		return SimpleType(name)
+	}
+	if alias, ok := typeAliases[name]; ok {
+		return SimpleType(alias)
+	}
+	return t
+}
+
+// Valid reports whether t solely consists of the canonical types listed in the
+// Type* constants. Use Normalize to convert aliases beforehand.
+func (t TypeExpr) Valid() bool {
+	switch {
+	case t.IsUnion():
+		for _, m := range t.Union {
+			if !m.Valid() {
+				return false
+			}
+		}
+		return true
+	case t.Elem != nil:
+		return (t.Name == TypeArray || t.Name == TypeObject) && t.Elem.Valid()
+	}
+
+	return knownTypes[t.Name]
+}
+
+// String returns the type expression of t, e.g. `string | array<string>`
+func (t TypeExpr) String() string {
+	switch {
+	case t.IsUnion():
+		s := make([]string, len(t.Union))
+		for i, u := range t.Union {
+			s[i] = u.String()
+		}
+		return strings.Join(s, " | ")
+	case t.Elem != nil:
+		return fmt.Sprintf("%s<%s>", t.Name, t.Elem)
+	default:
+		return t.Name
+	}
+}
+
+// typeFrom returns the union of the type expressions in `v`, which are strings
+// or arrays of those
+func typeFrom(v []interface{}) (TypeExpr, error) {
+	u := make([]TypeExpr, 0, len(v))
+	for _, e := range v {
+		switch e := e.(type) {
+		case string:
+			u = append(u, Type(e).Expr())
+		case []interface{}:
+			t, err := typeFrom(e)
+			if err != nil {
+				return TypeExpr{}, err
+			}
+			u = append(u, t)
+		default:
+			return TypeExpr{}, fmt.Errorf("type must be a string or an array of types, got %T", e)
+		}
+	}
+	return UnionOf(u...), nil
+}
+
+// ParseType parses a type expression. The syntax is:
+//
+//	type   = term { "|" term }
+//	term   = name [ "<" type ">" ] | "(" type ")"
+//
+// Element types (`<...>`) are only allowed for arrays and objects. Names may
+// contain dots, so types can refer to documented objects (`dashboard.Panel`).
+// An empty expression yields the zero TypeExpr.
+func ParseType(s string) (TypeExpr, error) {
+	p := typeParser{in: s}
+	p.skipSpace()
+	if p.eof() {
+		return TypeExpr{}, nil
+	}
+
+	t, err := p.union()
+	if err != nil {
+		return TypeExpr{}, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return TypeExpr{}, p.errorf("unexpected %q", p.in[p.pos])
+	}
+	return t, nil
+}
+
+type typeParser struct {
+	in  string
+	pos int
+}
+
+var errUnexpectedEnd = errors.New("unexpected end of type expression")
+
+func (p *typeParser) union() (TypeExpr, error) {
+	var u []TypeExpr
+	for {
+		t, err := p.term()
+		if err != nil {
+			return TypeExpr{}, err
+		}
+		u = append(u, t)
+
+		p.skipSpace()
+		if !p.consume('|') {
+			return UnionOf(u...), nil
+		}
+	}
+}
+
+func (p *typeParser) term() (TypeExpr, error) {
+	p.skipSpace()
+	if p.eof() {
+		return TypeExpr{}, errUnexpectedEnd
+	}
+
+	if p.consume('(') {
+		t, err := p.union()
+		if err != nil {
+			return TypeExpr{}, err
+		}
+		p.skipSpace()
+		if !p.consume(')') {
+			return TypeExpr{}, p.errorf("expected ')'")
+		}
+		return t, nil
+	}
+
+	name := p.name()
+	if name == "" {
+		return TypeExpr{}, p.errorf("unexpected %q", p.in[p.pos])
+	}
+
+	p.skipSpace()
+	if !p.consume('<') {
+		return SimpleType(name), nil
+	}
+
+	if name != TypeArray && name != TypeObject {
+		return TypeExpr{}, p.errorf("type %s can't have an element type", name)
+	}
+
+	elem, err := p.union()
+	if err != nil {
+		return TypeExpr{}, err
+	}
+	p.skipSpace()
+	if !p.consume('>') {
+		return TypeExpr{}, p.errorf("expected '>'")
+	}
+
+	return TypeExpr{Name: name, Elem: &elem}, nil
+}
+
+func (p *typeParser) name() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.in[p.pos]
+		letter := c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+		inner := c == '.' || c == '-' || ('0' <= c && c <= '9')
+		if letter || (p.pos > start && inner) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.in[start:p.pos]
+}
+
+func (p *typeParser) consume(c byte) bool {
+	if !p.eof() && p.in[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) skipSpace() {
+	for !p.eof() && strings.ContainsRune(" \t\n", rune(p.in[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *typeParser) eof() bool {
+	return p.pos >= len(p.in)
+}
+
+func (p *typeParser) errorf(format string, a ...interface{}) error {
+	if p.eof() {
+		return errUnexpectedEnd
+	}
+	return fmt.Errorf("%s at position %d of %q", fmt.Sprintf(format, a...), p.pos, p.in)
+}
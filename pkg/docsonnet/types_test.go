@@ -0,0 +1,124 @@
+package docsonnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseType(t *testing.T) {
+	cases := []struct {
+		in   string
+		want TypeExpr
+		str  string
+	}{
+		{in: "", want: TypeExpr{}, str: ""},
+		{in: "string", want: SimpleType(TypeString), str: "string"},
+		{
+			in:   "string | array<string>",
+			want: UnionOf(SimpleType(TypeString), ArrayOf(SimpleType(TypeString))),
+			str:  "string | array<string>",
+		},
+		{
+			in:   "object<(number|string)>",
+			want: ObjectOf(UnionOf(SimpleType(TypeNumber), SimpleType(TypeString))),
+			str:  "object<number | string>",
+		},
+		{
+			in:   "(string | null) | dashboard.Panel",
+			want: UnionOf(SimpleType(TypeString), SimpleType(TypeNull), SimpleType("dashboard.Panel")),
+			str:  "string | null | dashboard.Panel",
+		},
+		{
+			in:   "array< array<any> >",
+			want: ArrayOf(ArrayOf(SimpleType(TypeAny))),
+			str:  "array<array<any>>",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := ParseType(c.in)
+			require.NoError(t, err)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, c.str, got.String())
+		})
+	}
+}
+
+func TestParseTypeErrors(t *testing.T) {
+	for _, in := range []string{
+		"string |",
+		"array<string",
+		"string<number>",
+		"(string",
+		"string number",
+		"| string",
+	} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseType(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTypeJSON(t *testing.T) {
+	arg := Argument{
+		Name: "foo",
+		Type: "string | array<string>",
+	}
+
+	data, err := json.Marshal(arg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "foo", "type": "string | array<string>", "default": null}`, string(data))
+
+	var got Argument
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, arg, got)
+
+	// unions may also be given as arrays, free-form strings are kept as-is
+	var v Value
+	require.NoError(t, json.Unmarshal([]byte(`{"type": ["string", "array<number>"]}`), &v))
+	assert.Equal(t, Type("string | array<number>"), v.Type)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"type": "string or number"}`), &v))
+	assert.Equal(t, Type("string or number"), v.Type)
+	assert.Equal(t, SimpleType("string or number"), v.Type.Expr())
+}
+
+func TestTypeCompatible(t *testing.T) {
+	// types are strings, like before type expressions were supported
+	v := Value{Type: TypeString}
+	assert.Equal(t, "string", string(v.Type))
+	assert.Equal(t, SimpleType(TypeString), v.Type.Expr())
+	assert.Equal(t, ArrayOf(SimpleType(TypeNumber)), Type("array<number>").Expr())
+}
+
+func TestNormalizeType(t *testing.T) {
+	cases := []struct {
+		in    string
+		want  string
+		valid bool
+	}{
+		{in: "bool", want: "boolean", valid: true},
+		{in: "func | String", want: "function | string", valid: true},
+		{in: "array<integer>", want: "array<number>", valid: true},
+		{in: "object<nil | bool>", want: "object<null | boolean>", valid: true},
+		{in: "dashboard.Panel", want: "dashboard.Panel", valid: false},
+		{in: "string | strng", want: "string | strng", valid: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			typ, err := ParseType(c.in)
+			require.NoError(t, err)
+
+			got := typ.Normalize()
+			assert.Equal(t, c.want, got.String())
+			assert.Equal(t, c.valid, got.Valid())
+			assert.Equal(t, c.valid, Type(c.in).Valid())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+	"github.com/jsonnet-libs/docsonnet/pkg/slug"
+)
+
+// AnchorStyle is the syntax of explicit anchors of headlines
+type AnchorStyle string
+
+const (
+	// AnchorsNone relies on the anchors the markdown engine creates from the
+	// headline text
+	AnchorsNone AnchorStyle = ""
+	// AnchorsHTML puts `<a id="fn-new"></a>` in front of headlines
+	AnchorsHTML AnchorStyle = "html"
+	// AnchorsAttribute appends `{#fn-new}` to headlines, as understood by
+	// Hugo, MkDocs (attr_list), Docusaurus, Pandoc and others
+	AnchorsAttribute AnchorStyle = "attribute"
+)
+
+// ParseAnchorStyle returns the AnchorStyle called `s`, "none" being
+// AnchorsNone
+func ParseAnchorStyle(s string) (AnchorStyle, error) {
+	switch style := AnchorStyle(s); style {
+	case AnchorsHTML, AnchorsAttribute:
+		return style, nil
+	case "none", "":
+		return AnchorsNone, nil
+	default:
+		return "", fmt.Errorf("unknown anchor style '%s', expected none, html or attribute", s)
+	}
+}
+
+// Kinds of fields, prefixing their explicit anchors
+const (
+	kindFn    = "fn"
+	kindObj   = "obj"
+	kindValue = "val"
+)
+
+// anchorID returns the explicit anchor of the field of `kind` at the dotted
+// `path`, e.g. `fn-dashboard-new`. It only depends on the path, so it stays
+// the same when e.g. the arguments of a function change.
+func anchorID(kind, path string) string {
+	return kind + "-" + strings.ReplaceAll(path, ".", "-")
+}
+
+// maxLevel is the deepest level of headlines known to markdown
+const maxLevel = 6
+
+// headline returns the headline of a field, along with its explicit anchor if
+// enabled. Levels deeper than maxLevel are lowered to it, so deeply nested
+// fields look like siblings of their parent.
+func headline(level int, text, id string, opts Opts) []md.Elem {
+	if level > maxLevel {
+		level = maxLevel
+	}
+
+	switch opts.Anchors {
+	case AnchorsHTML:
+		return []md.Elem{
+			md.Text(fmt.Sprintf(`<a id="%s"></a>`, id)),
+			md.Headline(level, text),
+		}
+	case AnchorsAttribute:
+		return []md.Elem{md.Headline(level, fmt.Sprintf("%s {#%s}", text, id))}
+	default:
+		return []md.Elem{md.Headline(level, text)}
+	}
+}
+
+// anchors returns the anchors of the headlines of the fields in `api`, by
+// their dotted path. These are the explicit ones if enabled, or those created
+// using opts.Slug otherwise.
+func anchors(api docsonnet.Fields, opts Opts) map[string]string {
+	out := make(map[string]string)
+	collectAnchors(api, "", "", opts.Slug.New(), opts, out)
+	return out
+}
+
+// collectAnchors adds the anchors of the fields in `api` to `out`, creating
+// slugs using `s`. Explicit anchors are prefixed with the dotted path `pkg` of
+// their package, if it is rendered into the page of another one.
+func collectAnchors(api docsonnet.Fields, pkg, path string, s *slug.Slugger, opts Opts, out map[string]string) {
+	anchor := func(kind, text, path string) string {
+		if opts.Anchors != AnchorsNone {
+			return anchorID(kind, joinPath(pkg, path))
+		}
+		return s.Slug(text)
+	}
+
+	for _, c := range categorize(api, opts) {
+		// the headline of the category precedes its fields
+		if c.name != "" {
+			s.Slug(c.name)
+		}
+
+		for _, k := range c.keys {
+			v := api[k]
+			switch {
+			case v.Function != nil:
+				out[path+k] = anchor(kindFn, "fn "+path+v.Function.Name, path+k)
+			case v.Object != nil:
+				obj := v.Object
+				out[path+k] = anchor(kindObj, "obj "+path+obj.Name, path+k)
+				collectAnchors(obj.Fields, pkg, path+obj.Name+".", s, opts, out)
+			case v.Value != nil:
+				val := v.Value
+				out[path+k] = anchor(kindValue, fmt.Sprintf("%s %s%s", val.Type, path, val.Name), path+k)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+func TestRenderAnchors(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "example",
+		Help: "see [[dashboard.new]]",
+		API: docsonnet.Fields{
+			"dashboard": {Object: &docsonnet.Object{Name: "dashboard", Fields: docsonnet.Fields{
+				"new": {Function: &docsonnet.Function{Name: "new", Args: []docsonnet.Argument{{Name: "title"}}}},
+			}}},
+			"replicas": {Value: &docsonnet.Value{Name: "replicas", Type: docsonnet.TypeNumber}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{Anchors: AnchorsHTML})
+	require.NoError(t, err)
+	res := pages["README.md"]
+	assert.Contains(t, res, "see [`dashboard.new`](#fn-dashboard-new)")
+	assert.Contains(t, res, "* [`fn new(title)`](#fn-dashboard-new)")
+	assert.Contains(t, res, "<a id=\"obj-dashboard\"></a>\n\n## obj dashboard")
+	assert.Contains(t, res, "<a id=\"fn-dashboard-new\"></a>\n\n### fn dashboard.new")
+	assert.Contains(t, res, "<a id=\"val-replicas\"></a>\n\n### number replicas")
+
+	pages, err = Render(pkg, Opts{Anchors: AnchorsAttribute})
+	require.NoError(t, err)
+	res = pages["README.md"]
+	assert.Contains(t, res, "* [`number replicas`](#val-replicas)")
+	assert.Contains(t, res, "### fn dashboard.new {#fn-dashboard-new}")
+	assert.NotContains(t, res, "<a id")
+}
+
+func TestParseAnchorStyle(t *testing.T) {
+	for in, want := range map[string]AnchorStyle{"": AnchorsNone, "none": AnchorsNone, "html": AnchorsHTML, "attribute": AnchorsAttribute} {
+		got, err := ParseAnchorStyle(in)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseAnchorStyle("markdown")
+	assert.Error(t, err)
+}
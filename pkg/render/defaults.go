@@ -0,0 +1,87 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-jsonnet/formatter"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+)
+
+// DefaultOpts control how default values of arguments and values are rendered
+type DefaultOpts struct {
+	// MaxLength truncates defaults in function signatures and the index that
+	// are longer than this. Zero disables truncation.
+	MaxLength int
+	// BlockLength is the length from which the default of a value is rendered
+	// as a multi-line code block instead of inline. Zero disables code blocks.
+	BlockLength int
+}
+
+// truncation marks defaults that were cut off because of MaxLength
+const truncation = "..."
+
+// formatDefault returns the Jsonnet representation of `v`, on a single line.
+// Defaults longer than `opts.MaxLength` characters are truncated.
+func formatDefault(v interface{}, opts DefaultOpts) string {
+	s := jsonnetLiteral(v, false)
+	if opts.MaxLength > 0 && utf8.RuneCountInString(s) > opts.MaxLength {
+		cut := opts.MaxLength - len(truncation)
+		if cut < 0 {
+			cut = 0
+		}
+		s = string([]rune(s)[:cut]) + truncation
+	}
+	return s
+}
+
+// renderDefault renders `v` as the default of a value. Short defaults are
+// rendered inline, long ones as a formatted code block.
+func renderDefault(v interface{}, opts DefaultOpts) []md.Elem {
+	label := md.Italic(md.Text("Default value:"))
+
+	s := jsonnetLiteral(v, false)
+	if opts.BlockLength <= 0 || utf8.RuneCountInString(s) < opts.BlockLength {
+		return []md.Elem{md.Paragraph(label, md.Code(md.Text(s)))}
+	}
+
+	return []md.Elem{
+		label,
+		md.CodeBlock("jsonnet", jsonnetLiteral(v, true)),
+	}
+}
+
+// jsonnetLiteral formats `v` as a Jsonnet literal, either on a single line or
+// spanning multiple lines. If formatting fails, the JSON representation is
+// used instead, and as a last resort Go's string representation.
+func jsonnetLiteral(v interface{}, multiline bool) string {
+	var d []byte
+	var err error
+	if multiline {
+		d, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		d, err = json.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	opts := formatter.Options{
+		PadObjects:       false,
+		PadArrays:        false,
+		PrettyFieldNames: true,
+		StringStyle:      formatter.StringStyleSingle,
+	}
+	if multiline {
+		opts.Indent = 2
+	}
+
+	s, err := formatter.Format("(default)", string(d), opts)
+	if err != nil {
+		return string(d)
+	}
+
+	return strings.TrimSpace(s)
+}
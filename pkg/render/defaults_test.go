@@ -0,0 +1,46 @@
+package render
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDefault(t *testing.T) {
+	cases := []struct {
+		name string
+		in   interface{}
+		opts DefaultOpts
+		want string
+	}{
+		{name: "string", in: "foo", want: "'foo'"},
+		{name: "number", in: 1.5, want: "1.5"},
+		{name: "object", in: map[string]interface{}{"a": 1, "b-c": []interface{}{1, 2}}, want: "{a: 1, 'b-c': [1, 2]}"},
+		{name: "truncated", in: []interface{}{"foo", "bar", "baz"}, opts: DefaultOpts{MaxLength: 12}, want: "['foo', '..."},
+		{name: "truncated runes", in: "ääääääääääääää", opts: DefaultOpts{MaxLength: 12}, want: "'ääääääää..."},
+		{name: "short enough", in: []interface{}{"foo"}, opts: DefaultOpts{MaxLength: 12}, want: "['foo']"},
+		{name: "unmarshalable", in: math.Inf(1), want: "+Inf"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, formatDefault(c.in, c.opts))
+		})
+	}
+}
+
+func TestRenderDefault(t *testing.T) {
+	v := map[string]interface{}{"labels": map[string]interface{}{"app": "grafana"}}
+
+	inline := md.Doc(renderDefault(v, DefaultOpts{})...).String()
+	assert.Equal(t, "*Default value:* `{labels: {app: 'grafana'}}`", inline)
+
+	block := md.Doc(renderDefault(v, DefaultOpts{BlockLength: 20})...).String()
+	assert.Equal(t, "*Default value:*\n\n```jsonnet\n{\n  labels: {\n    app: 'grafana',\n  },\n}\n```", block)
+
+	// counted in characters, not bytes
+	runes := md.Doc(renderDefault("äääää", DefaultOpts{BlockLength: 8})...).String()
+	assert.Equal(t, "*Default value:* `'äääää'`", runes)
+}
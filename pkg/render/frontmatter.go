@@ -0,0 +1,126 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+)
+
+// FrontmatterOpts control the frontmatter at the top of each page
+type FrontmatterOpts struct {
+	// Disable leaves out the frontmatter, e.g. for docs rendered by GitHub
+	Disable bool
+	// Fields are added to the frontmatter next to the permalink, and may
+	// replace it. Values are text/templates executed with the PageData of the
+	// page, e.g. `{{ .Title }}`. Their output is parsed as YAML, so that
+	// `{{ .Weight }}` is a number and `[jsonnet, {{ .Package.Name }}]` a list.
+	// Fields whose output is empty are left out.
+	Fields map[string]string
+}
+
+// frontmatter returns the frontmatter of the page `p`, or an empty string if
+// disabled
+func frontmatter(p PageData) (string, error) {
+	opts := p.opts.Frontmatter
+	if opts.Disable {
+		return "", nil
+	}
+
+	data := map[string]interface{}{
+		"permalink": p.Permalink,
+	}
+	if p.opts.Nav == NavHugo {
+		for k, v := range hugoFrontmatter(p) {
+			data[k] = v
+		}
+	}
+
+	keys := make([]string, 0, len(opts.Fields))
+	for k := range opts.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		t, err := template.New(k).Funcs(templateFuncs).Option("missingkey=error").Parse(opts.Fields[k])
+		if err != nil {
+			return "", fmt.Errorf("parsing frontmatter field '%s': %w", k, err)
+		}
+
+		var s strings.Builder
+		if err := t.Execute(&s, p); err != nil {
+			return "", fmt.Errorf("frontmatter field '%s': %w", k, err)
+		}
+
+		if s.Len() == 0 {
+			delete(data, k)
+			continue
+		}
+		data[k] = yamlValue(s.String())
+	}
+
+	if len(data) == 0 {
+		return "", nil
+	}
+	return md.Frontmatter(data).String(), nil
+}
+
+// yamlValue returns `s` parsed as a YAML scalar or list, or `s` itself if it is
+// anything else, like text that happens to contain a colon. Only true and false
+// are booleans, unlike in YAML 1.1 yes, no, on and off stay text.
+func yamlValue(s string) interface{} {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+
+	switch v := v.(type) {
+	case string, int, float64:
+		return v
+	case bool:
+		return yamlBool(v, s)
+	case []interface{}:
+		// the items as written
+		var texts []string
+		if err := yaml.Unmarshal([]byte(s), &texts); err != nil {
+			return s
+		}
+		for i, item := range v {
+			if b, ok := item.(bool); ok {
+				v[i] = yamlBool(b, texts[i])
+			}
+		}
+		return v
+	default:
+		return s
+	}
+}
+
+// yamlBool returns `b` if it was written as true or false, and the `text` it
+// was parsed from otherwise
+func yamlBool(b bool, text string) interface{} {
+	text = strings.TrimSpace(text)
+	if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+		return b
+	}
+	return text
+}
+
+// sentenceEnd matches the punctuation ending a sentence
+var sentenceEnd = regexp.MustCompile(`[.!?](\s|$)`)
+
+// firstSentence returns the first sentence of the first paragraph of `help`,
+// on a single line
+func firstSentence(help string) string {
+	para, _, _ := strings.Cut(strings.TrimSpace(help), "\n\n")
+	if loc := sentenceEnd.FindStringIndex(para); loc != nil {
+		para = para[:loc[0]+1]
+	}
+	return strings.Join(strings.Fields(para), " ")
+}
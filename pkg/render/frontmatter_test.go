@@ -0,0 +1,103 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstSentence(t *testing.T) {
+	cases := map[string]string{
+		"":                                 "",
+		"no punctuation":                   "no punctuation",
+		"First one. Second one.":           "First one.",
+		"Spans\nlines. Then more":          "Spans lines.",
+		"Version 1.2 is out! Really":       "Version 1.2 is out!",
+		"First paragraph\n\nSecond. Para.": "First paragraph",
+	}
+
+	for help, want := range cases {
+		assert.Equal(t, want, firstSentence(help), help)
+	}
+}
+
+func TestFrontmatter(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "lib",
+		Help: "A library. With details.",
+		Sub: map[string]docsonnet.Package{
+			"b": {Name: "b", Help: "Second."},
+			"a": {Name: "a", Help: "First: the one."},
+		},
+	}
+
+	opts := Opts{Frontmatter: FrontmatterOpts{Fields: map[string]string{
+		"title":       "{{ .Title }}",
+		"weight":      "{{ .Weight }}",
+		"description": "{{ .Description }}",
+		"tags":        "[jsonnet, {{ .Package.Name }}]",
+		"layout":      "docs",
+		"draft":       "",
+	}}}
+
+	pages, err := Render(pkg, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, `---
+description: A library.
+layout: docs
+permalink: /
+tags:
+- jsonnet
+- lib
+title: lib
+weight: 1
+---
+
+# lib
+
+A library. With details.
+
+* [a](a.md)
+* [b](b.md)`, pages["README.md"])
+
+	assert.Contains(t, pages["a.md"], "description: 'First: the one.'\n")
+	assert.Contains(t, pages["a.md"], "title: a\nweight: 1\n")
+	assert.Contains(t, pages["b.md"], "title: b\nweight: 2\n")
+}
+
+func TestYAMLValue(t *testing.T) {
+	cases := map[string]interface{}{
+		"docs":             "docs",
+		"1":                1,
+		"1.5":              1.5,
+		"true":             true,
+		"False":            false,
+		"no":               "no",
+		"on":               "on",
+		"[jsonnet, yes]":   []interface{}{"jsonnet", "yes"},
+		"[1, true]":        []interface{}{1, true},
+		"First: the one.":  "First: the one.",
+		"{a: 1}":           "{a: 1}",
+		"[[nested], list]": "[[nested], list]",
+	}
+	for s, want := range cases {
+		assert.Equal(t, want, yamlValue(s), s)
+	}
+}
+
+func TestFrontmatterDisabled(t *testing.T) {
+	pages, err := Render(docsonnet.Package{Name: "lib", Help: "A library"}, Opts{Frontmatter: FrontmatterOpts{Disable: true}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "# lib\n\nA library", pages["README.md"])
+}
+
+func TestFrontmatterError(t *testing.T) {
+	_, err := Render(docsonnet.Package{Name: "lib"}, Opts{Frontmatter: FrontmatterOpts{Fields: map[string]string{
+		"title": "{{ .Missing }}",
+	}}})
+	assert.Error(t, err)
+}
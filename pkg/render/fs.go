@@ -12,7 +12,10 @@ func To(pkg docsonnet.Package, dir string, opts Opts) (int, error) {
 		return 0, err
 	}
 
-	data := Render(pkg, opts)
+	data, err := Render(pkg, opts)
+	if err != nil {
+		return 0, err
+	}
 
 	n := 0
 	for k, v := range data {
@@ -0,0 +1,212 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// NavStyle is the site generator to write navigation files for, next to the
+// pages
+type NavStyle string
+
+const (
+	// NavNone writes no navigation files
+	NavNone NavStyle = ""
+	// NavMkDocs writes a mkdocs.yml holding the `nav` section, to be included
+	// into the actual configuration using `INHERIT`
+	NavMkDocs NavStyle = "mkdocs"
+	// NavDocusaurus writes a sidebars.js, and a _category_.json to each
+	// directory for autogenerated sidebars
+	NavDocusaurus NavStyle = "docusaurus"
+	// NavHugo writes the pages of packages that have sub packages to the
+	// _index.md of their directory, making it a section. All pages get a title
+	// and weight.
+	NavHugo NavStyle = "hugo"
+)
+
+// ParseNavStyle returns the NavStyle called `s`, "none" being NavNone
+func ParseNavStyle(s string) (NavStyle, error) {
+	switch style := NavStyle(s); style {
+	case NavMkDocs, NavDocusaurus, NavHugo:
+		return style, nil
+	case "none", "":
+		return NavNone, nil
+	default:
+		return "", fmt.Errorf("unknown navigation style '%s', expected none, mkdocs, docusaurus or hugo", s)
+	}
+}
+
+// navPage is a page in the navigation tree
+type navPage struct {
+	name string
+	// file of the page, relative to the output directory
+	file string
+	// weight is the position among its siblings, starting at 1
+	weight int
+	subs   []navPage
+}
+
+// dir is the directory holding the pages of the sub packages, if any
+func (p navPage) dir() string {
+	return path.Dir(p.file)
+}
+
+// navTree returns the navigation tree of `pkg`, its sub packages sorted by name
+func navTree(pkg docsonnet.Package, parents []string, root bool, weight int, opts Opts) navPage {
+	page := navPage{
+		name:   pkg.Name,
+		file:   pageFile(pkg, parents, root, opts),
+		weight: weight,
+	}
+	page.subs = navSubs(pkg, parents, root, opts)
+	return page
+}
+
+// navSubs returns the navigation trees of the sub packages of `pkg` that have
+// a page of their own. Those below sub packages rendered into the page of
+// `pkg` take their place.
+func navSubs(pkg docsonnet.Package, parents []string, root bool, opts Opts) []navPage {
+	var subs []navPage
+	path := subParents(pkg, parents, root)
+	for i, name := range subNames(pkg) {
+		sub := pkg.Sub[name]
+		if ownPage(sub, opts) {
+			subs = append(subs, navTree(sub, path, false, i+1, opts))
+		} else {
+			subs = append(subs, navSubs(sub, path, false, opts)...)
+		}
+	}
+	return subs
+}
+
+// subNames returns the names of the sub packages of `pkg`, sorted
+func subNames(pkg docsonnet.Package) []string {
+	names := make([]string, 0, len(pkg.Sub))
+	for _, s := range pkg.Sub {
+		names = append(names, s.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nav adds the navigation files for the pages of `pkg` to `out`
+func nav(pkg docsonnet.Package, opts Opts, out map[string]string) error {
+	tree := navTree(pkg, nil, true, 1, opts)
+
+	switch opts.Nav {
+	case NavMkDocs:
+		return mkdocsNav(tree, out)
+	case NavDocusaurus:
+		return docusaurusNav(tree, out)
+	}
+	return nil
+}
+
+// mkdocsNav adds a mkdocs.yml holding the nav section. Pages of packages that
+// have sub packages are the index of their section.
+func mkdocsNav(tree navPage, out map[string]string) error {
+	var entry func(p navPage) yaml.MapSlice
+	entry = func(p navPage) yaml.MapSlice {
+		if len(p.subs) == 0 {
+			return yaml.MapSlice{{Key: p.name, Value: p.file}}
+		}
+
+		items := []interface{}{p.file}
+		for _, s := range p.subs {
+			items = append(items, entry(s))
+		}
+		return yaml.MapSlice{{Key: p.name, Value: items}}
+	}
+
+	// the root page is not a section of its own
+	items := []interface{}{yaml.MapSlice{{Key: tree.name, Value: tree.file}}}
+	for _, s := range tree.subs {
+		items = append(items, entry(s))
+	}
+
+	data, err := yaml.Marshal(yaml.MapSlice{{Key: "nav", Value: items}})
+	if err != nil {
+		return err
+	}
+	out["mkdocs.yml"] = string(data)
+	return nil
+}
+
+// docusaurusCategory is an entry of a sidebar, and the content of
+// _category_.json files
+type docusaurusCategory struct {
+	Type     string          `json:"type,omitempty"`
+	Label    string          `json:"label"`
+	Position int             `json:"position,omitempty"`
+	Link     *docusaurusLink `json:"link,omitempty"`
+	Items    []interface{}   `json:"items,omitempty"`
+}
+
+type docusaurusLink struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// docusaurusID returns the id of the doc at `file`
+func docusaurusID(file string) string {
+	return strings.TrimSuffix(file, ".md")
+}
+
+// docusaurusNav adds a sidebars.js with a sidebar named after the root package,
+// and a _category_.json to each directory
+func docusaurusNav(tree navPage, out map[string]string) error {
+	var item func(p navPage) (interface{}, error)
+	item = func(p navPage) (interface{}, error) {
+		if len(p.subs) == 0 {
+			return docusaurusID(p.file), nil
+		}
+
+		link := &docusaurusLink{Type: "doc", ID: docusaurusID(p.file)}
+		category, err := json.MarshalIndent(docusaurusCategory{Label: p.name, Position: p.weight, Link: link}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		out[path.Join(p.dir(), "_category_.json")] = string(category) + "\n"
+
+		c := docusaurusCategory{Type: "category", Label: p.name, Link: link}
+		for _, s := range p.subs {
+			i, err := item(s)
+			if err != nil {
+				return nil, err
+			}
+			c.Items = append(c.Items, i)
+		}
+		return c, nil
+	}
+
+	items := []interface{}{docusaurusID(tree.file)}
+	for _, s := range tree.subs {
+		i, err := item(s)
+		if err != nil {
+			return err
+		}
+		items = append(items, i)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{tree.name: items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	out["sidebars.js"] = fmt.Sprintf("module.exports = %s;\n", data)
+	return nil
+}
+
+// hugoFrontmatter returns the frontmatter Hugo orders the page `p` by
+func hugoFrontmatter(p PageData) map[string]interface{} {
+	return map[string]interface{}{
+		"title":  p.Package.Name,
+		"weight": p.Weight,
+	}
+}
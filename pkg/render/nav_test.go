@@ -0,0 +1,97 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// navPackage has a sub package with sub packages of its own, and one without
+var navPackage = docsonnet.Package{
+	Name: "lib",
+	Sub: map[string]docsonnet.Package{
+		"b": {Name: "b"},
+		"a": {Name: "a", Sub: map[string]docsonnet.Package{
+			"x": {Name: "x"},
+		}},
+	},
+}
+
+func TestParseNavStyle(t *testing.T) {
+	for _, s := range []string{"", "none"} {
+		style, err := ParseNavStyle(s)
+		require.NoError(t, err)
+		assert.Equal(t, NavNone, style)
+	}
+
+	style, err := ParseNavStyle("hugo")
+	require.NoError(t, err)
+	assert.Equal(t, NavHugo, style)
+
+	_, err = ParseNavStyle("jekyll")
+	assert.Error(t, err)
+}
+
+func TestNavMkDocs(t *testing.T) {
+	pages, err := Render(navPackage, Opts{Nav: NavMkDocs})
+	require.NoError(t, err)
+
+	assert.Equal(t, `nav:
+- lib: README.md
+- a:
+  - a/index.md
+  - x: a/x.md
+- b: b.md
+`, pages["mkdocs.yml"])
+}
+
+func TestNavDocusaurus(t *testing.T) {
+	pages, err := Render(navPackage, Opts{Nav: NavDocusaurus})
+	require.NoError(t, err)
+
+	assert.Equal(t, `module.exports = {
+  "lib": [
+    "README",
+    {
+      "type": "category",
+      "label": "a",
+      "link": {
+        "type": "doc",
+        "id": "a/index"
+      },
+      "items": [
+        "a/x"
+      ]
+    },
+    "b"
+  ]
+};
+`, pages["sidebars.js"])
+
+	assert.Equal(t, `{
+  "label": "a",
+  "position": 1,
+  "link": {
+    "type": "doc",
+    "id": "a/index"
+  }
+}
+`, pages["a/_category_.json"])
+}
+
+func TestNavHugo(t *testing.T) {
+	pages, err := Render(navPackage, Opts{Nav: NavHugo})
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(pages))
+	for k := range pages {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"_index.md", "a/_index.md", "a/x.md", "b.md"}, keys)
+
+	assert.Contains(t, pages["_index.md"], "* [a](a/_index.md)\n* [b](b.md)")
+	assert.Contains(t, pages["b.md"], "title: b\nweight: 2\n")
+	assert.Contains(t, pages["a/x.md"], "title: x\nweight: 1\n")
+}
@@ -0,0 +1,219 @@
+package render
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// FieldOrder returns the names of the fields of `api`, in the order they are
+// listed in the index and the sections of a page
+type FieldOrder func(api docsonnet.Fields) []string
+
+// fieldOrders are the orders selectable by name, see ParseFieldOrder
+var fieldOrders = map[string]FieldOrder{
+	"default":      OrderDefault,
+	"alphabetical": OrderAlphabetical,
+	"kind":         OrderKind,
+	"source":       OrderSource,
+	"explicit":     OrderExplicit,
+}
+
+// FieldOrders returns the names of the orders known to ParseFieldOrder
+func FieldOrders() []string {
+	return []string{"default", "alphabetical", "kind", "source", "explicit"}
+}
+
+// ParseFieldOrder returns the FieldOrder called `s`, an empty one being
+// OrderDefault
+func ParseFieldOrder(s string) (FieldOrder, error) {
+	if s == "" {
+		return OrderDefault, nil
+	}
+	if o, ok := fieldOrders[s]; ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("unknown field order '%s', expected one of %s", s, strings.Join(FieldOrders(), ", "))
+}
+
+// sortFields returns the names of the fields of `api` in the order of
+// opts.Order
+func sortFields(api docsonnet.Fields, opts Opts) []string {
+	if opts.Order == nil {
+		return OrderDefault(api)
+	}
+	return opts.Order(api)
+}
+
+// sortedBy returns the names of the fields of `api` sorted using `less`, and
+// by name if neither comes first
+func sortedBy(api docsonnet.Fields, less func(a, b string) bool) []string {
+	keys := make([]string, 0, len(api))
+	for k := range api {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	return keys
+}
+
+// OrderDefault lists constructors (`new*`) first, followed by the other
+// functions and then the remaining fields, each by name
+func OrderDefault(api docsonnet.Fields) []string {
+	isNew := func(k string) bool {
+		return strings.HasPrefix(strings.ToLower(k), "new")
+	}
+
+	return sortedBy(api, func(a, b string) bool {
+		if isNew(a) != isNew(b) {
+			return isNew(a)
+		}
+		return api[a].Function != nil && api[b].Function == nil
+	})
+}
+
+// OrderAlphabetical lists the fields by name
+func OrderAlphabetical(api docsonnet.Fields) []string {
+	return sortedBy(api, func(a, b string) bool { return false })
+}
+
+// OrderKind lists functions first, followed by objects and then values, each
+// by name
+func OrderKind(api docsonnet.Fields) []string {
+	rank := func(f docsonnet.Field) int {
+		switch {
+		case f.Function != nil:
+			return 0
+		case f.Object != nil:
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	return sortedBy(api, func(a, b string) bool {
+		return rank(api[a]) < rank(api[b])
+	})
+}
+
+// OrderSource lists the fields in the order of their docstrings in the source,
+// which is known when using docsonnet.ExtractStatic. Fields without a location
+// follow in the default order.
+func OrderSource(api docsonnet.Fields) []string {
+	fallback := position(OrderDefault(api))
+
+	return sortedBy(api, func(a, b string) bool {
+		la, lb := api[a].Location, api[b].Location
+		switch {
+		case la == nil && lb == nil:
+			return fallback[a] < fallback[b]
+		case la == nil || lb == nil:
+			return la != nil
+		case la.File != lb.File:
+			return la.File < lb.File
+		case la.Line != lb.Line:
+			return la.Line < lb.Line
+		default:
+			return la.Column < lb.Column
+		}
+	})
+}
+
+// OrderExplicit lists the fields as set in their docstrings using `d.group` and
+// `d.order`. Fields without a group come first, followed by the groups, which
+// are ordered by the lowest position of their fields and then by name. Within
+// a group, fields with a position come first. The others follow in the
+// default order.
+func OrderExplicit(api docsonnet.Fields) []string {
+	fallback := position(OrderDefault(api))
+	groups := groupRanks(api)
+
+	return sortedBy(api, func(a, b string) bool {
+		fa, fb := api[a], api[b]
+		if fa.Group != fb.Group {
+			return groupLess(groups, fa.Group, fb.Group)
+		}
+
+		if order(fa) != order(fb) {
+			return order(fa) < order(fb)
+		}
+		return fallback[a] < fallback[b]
+	})
+}
+
+// order returns the position of `f` set using `d.order`, or infinity if unset
+func order(f docsonnet.Field) float64 {
+	if f.Order == nil {
+		return math.Inf(1)
+	}
+	return *f.Order
+}
+
+// groupRanks returns the lowest position of the fields of each group in `api`
+func groupRanks(api docsonnet.Fields) map[string]float64 {
+	groups := make(map[string]float64)
+	for _, f := range api {
+		if o, ok := groups[f.Group]; !ok || order(f) < o {
+			groups[f.Group] = order(f)
+		}
+	}
+	return groups
+}
+
+// groupLess reports whether the group `a` is listed before `b`. Fields without
+// a group come first, followed by the groups ordered by their rank and name.
+func groupLess(ranks map[string]float64, a, b string) bool {
+	switch {
+	case a == "" || b == "":
+		return a == "" && b != ""
+	case ranks[a] != ranks[b]:
+		return ranks[a] < ranks[b]
+	default:
+		return a < b
+	}
+}
+
+// category is a group of fields, which is listed below a headline of its name
+// unless empty
+type category struct {
+	name string
+	keys []string
+}
+
+// categorize splits the fields of `api` into their groups, see groupLess. The
+// fields of each are listed in the order of opts.Order.
+func categorize(api docsonnet.Fields, opts Opts) []category {
+	var out []category
+	index := make(map[string]int)
+	for _, k := range sortFields(api, opts) {
+		g := api[k].Group
+		i, ok := index[g]
+		if !ok {
+			i = len(out)
+			index[g] = i
+			out = append(out, category{name: g})
+		}
+		out[i].keys = append(out[i].keys, k)
+	}
+
+	ranks := groupRanks(api)
+	sort.SliceStable(out, func(i, j int) bool {
+		return groupLess(ranks, out[i].name, out[j].name)
+	})
+	return out
+}
+
+// position returns the index of each of `keys`
+func position(keys []string) map[string]int {
+	out := make(map[string]int, len(keys))
+	for i, k := range keys {
+		out[k] = i
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grouped(f docsonnet.Field, group string, order float64) docsonnet.Field {
+	f.Group = group
+	if order != 0 {
+		f.Order = &order
+	}
+	return f
+}
+
+func located(f docsonnet.Field, file string, line int) docsonnet.Field {
+	f.Location = &docsonnet.Location{File: file, Line: line}
+	return f
+}
+
+func TestFieldOrders(t *testing.T) {
+	api := docsonnet.Fields{
+		"new":      grouped(located(dfn(), "main.libsonnet", 3), "Constructors", 1),
+		"withB":    grouped(located(dfn(), "main.libsonnet", 1), "Modifiers", 2),
+		"withA":    grouped(located(dfn(), "main.libsonnet", 2), "Modifiers", 0),
+		"metadata": located(dobj(), "a.libsonnet", 9),
+		"zeta":     {Value: &docsonnet.Value{}},
+		"alpha":    dfn(),
+	}
+
+	cases := map[string][]string{
+		"default":      {"new", "alpha", "withA", "withB", "metadata", "zeta"},
+		"alphabetical": {"alpha", "metadata", "new", "withA", "withB", "zeta"},
+		"kind":         {"alpha", "new", "withA", "withB", "metadata", "zeta"},
+		"source":       {"metadata", "withB", "withA", "new", "alpha", "zeta"},
+		"explicit":     {"alpha", "metadata", "zeta", "new", "withB", "withA"},
+	}
+
+	for name, want := range cases {
+		order, err := ParseFieldOrder(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, order(api), name)
+	}
+
+	_, err := ParseFieldOrder("random")
+	assert.Error(t, err)
+}
+
+func TestFieldOrderConsistent(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"b": {Function: &docsonnet.Function{Name: "b"}},
+			"a": {Value: &docsonnet.Value{Name: "a", Type: docsonnet.TypeString}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{Order: OrderAlphabetical})
+	require.NoError(t, err)
+
+	assert.Contains(t, pages["README.md"], "* [`string a`](#string-a)\n* [`fn b()`](#fn-b)")
+	assert.Regexp(t, "(?s)### string a.*### fn b", pages["README.md"])
+}
+
+func TestCategories(t *testing.T) {
+	fn := func(name string) docsonnet.Field {
+		return docsonnet.Field{Function: &docsonnet.Function{Name: name}}
+	}
+
+	metadata := grouped(dobj(), "Mixins", 0)
+	metadata.Object.Name = "metadata"
+	metadata.Object.Fields = docsonnet.Fields{
+		"withName":   grouped(fn("withName"), "Modifiers", 0),
+		"withLabels": fn("withLabels"),
+	}
+
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"new":      grouped(fn("new"), "Constructors", 0),
+			"metadata": metadata,
+			"alpha":    fn("alpha"),
+		},
+	}
+
+	pages, err := Render(pkg, Opts{})
+	require.NoError(t, err)
+	page := pages["README.md"]
+
+	assert.Contains(t, page, "* [`fn alpha()`](#fn-alpha)\n"+
+		"* *Constructors*\n"+
+		"  * [`fn new()`](#fn-new)\n"+
+		"* *Mixins*\n"+
+		"  * [`obj metadata`](#obj-metadata)\n"+
+		"    * [`fn withLabels()`](#fn-metadatawithlabels)\n"+
+		"    * *Modifiers*\n"+
+		"      * [`fn withName()`](#fn-metadatawithname)\n")
+	assert.Regexp(t, "(?s)\n### fn alpha\n.*"+
+		"\n### Constructors\n.*\n#### fn new\n.*"+
+		"\n### Mixins\n.*\n#### obj metadata\n.*"+
+		"\n##### fn metadata.withLabels\n.*"+
+		"\n##### Modifiers\n.*\n###### fn metadata.withName\n", page)
+}
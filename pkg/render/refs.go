@@ -0,0 +1,381 @@
+package render
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+	"github.com/jsonnet-libs/docsonnet/pkg/slug"
+)
+
+// expRefs matches references to other fields in help texts, written as
+// `[[dashboard.new]]` or `{@link dashboard.new}`. Code spans and blocks are
+// matched as well, to leave references in them alone.
+var expRefs = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|\\[\\[([\\w.$-]+)\\]\\]|\\{@link\\s+([\\w.$-]+)\\}")
+
+// target is the location of a field or package in the rendered pages
+type target struct {
+	page   string
+	anchor string
+	// isType is set for packages and objects, which types may refer to
+	isType bool
+}
+
+// symbols is the symbol table of a package tree, used to resolve references
+// and type names to links
+type symbols struct {
+	// targets by dotted path, which is empty for the root package
+	targets map[string]target
+	// anchors of the fields of each package by their path within it, by the
+	// dotted path of the package
+	anchors map[string]map[string]string
+	// unresolved references, for reporting
+	unresolved []string
+
+	opts Opts
+}
+
+// newSymbols builds the symbol table of the tree below `pkg`, with anchors as
+// created by opts.Slug or opts.Anchors
+func newSymbols(pkg docsonnet.Package, opts Opts) *symbols {
+	s := &symbols{
+		targets: make(map[string]target),
+		anchors: make(map[string]map[string]string),
+		opts:    opts,
+	}
+	s.collect(pkg, nil, true, nil)
+	return s
+}
+
+// ResolveRefs replaces references to fields and packages in the help texts of
+// `pkg` by links to them. References are dotted paths like in
+// docsonnet.Package.Lookup, which are looked up relative to the object holding
+// the documented field and its parents first, then relative to the package of
+// the help text, and then relative to `pkg`. Fails if any reference
+// can't be resolved. Anchors are created as configured by opts.
+func ResolveRefs(pkg docsonnet.Package, opts Opts) (docsonnet.Package, error) {
+	return newSymbols(pkg, opts).resolveRefs(pkg)
+}
+
+func (s *symbols) resolveRefs(pkg docsonnet.Package) (docsonnet.Package, error) {
+	out := s.resolvePackage(pkg, nil, true)
+	if len(s.unresolved) > 0 {
+		return docsonnet.Package{}, fmt.Errorf("unresolved references: %s", strings.Join(s.unresolved, ", "))
+	}
+	return out, nil
+}
+
+// page is a rendered page, along with the slugger creating the anchors of its
+// headlines
+type page struct {
+	file  string
+	slugs *slug.Slugger
+}
+
+// collect records the targets of `pkg`, its fields and sub packages. `host` is
+// the page of the parent if `pkg` is rendered into it, or nil if it has a page
+// of its own.
+func (s *symbols) collect(pkg docsonnet.Package, parents []string, root bool, host *page) {
+	prefix := packagePath(pkg, parents, root)
+	title := pageTitle(pkg, parents)
+
+	idPrefix := prefix
+	if host == nil {
+		host = &page{file: pageFile(pkg, parents, root, s.opts), slugs: s.opts.Slug.New()}
+		host.slugs.Slug(title)
+		s.targets[prefix] = target{page: host.file, isType: true}
+		idPrefix = ""
+	} else {
+		s.targets[prefix] = target{page: host.file, anchor: host.slugs.Slug(title), isType: true}
+	}
+
+	anchors := make(map[string]string)
+	collectAnchors(pkg.API, idPrefix, "", host.slugs, s.opts, anchors)
+	s.anchors[prefix] = anchors
+	for p, anchor := range anchors {
+		f, _ := pkg.Lookup(p)
+		s.targets[joinPath(prefix, p)] = target{page: host.file, anchor: anchor, isType: f != nil && f.Object != nil}
+	}
+
+	// in the order they are rendered, as that of slugs matters
+	for _, name := range subNames(pkg) {
+		sub := pkg.Sub[name]
+		if ownPage(sub, s.opts) {
+			s.collect(sub, subParents(pkg, parents, root), false, nil)
+		} else {
+			s.collect(sub, subParents(pkg, parents, root), false, host)
+		}
+	}
+}
+
+// pageTitle returns the title of the page of `pkg`, its dotted path including
+// the root package only for itself
+func pageTitle(pkg docsonnet.Package, parents []string) string {
+	return strings.Join(append(parents[:len(parents):len(parents)], pkg.Name), ".")
+}
+
+// packagePath returns the dotted path of `pkg` below the root package
+func packagePath(pkg docsonnet.Package, parents []string, root bool) string {
+	if root {
+		return ""
+	}
+	return strings.Join(append(parents[:len(parents):len(parents)], pkg.Name), ".")
+}
+
+// subParents returns the parents of the sub packages of `pkg`
+func subParents(pkg docsonnet.Package, parents []string, root bool) []string {
+	if root {
+		return parents
+	}
+	return append(parents[:len(parents):len(parents)], pkg.Name)
+}
+
+// joinPath appends `name` to the dotted path `parent`
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "." + name
+}
+
+func (s *symbols) resolvePackage(pkg docsonnet.Package, parents []string, root bool) docsonnet.Package {
+	l := s.linker(pkg, parents, root)
+
+	pkg.Help = l.link(pkg.Help, "package "+pkg.Name, "")
+	pkg.API = l.fields(pkg.API, "")
+
+	if pkg.Sub != nil {
+		subs := make(map[string]docsonnet.Package, len(pkg.Sub))
+		for k, sub := range pkg.Sub {
+			subs[k] = s.resolvePackage(sub, subParents(pkg, parents, root), false)
+		}
+		pkg.Sub = subs
+	}
+	return pkg
+}
+
+// linker creates the links of a single page
+type linker struct {
+	symbols *symbols
+	// page the links are rendered to
+	page string
+	// scope is the path of the package of the page
+	scope string
+}
+
+// linker returns the linker of the page of `pkg`
+func (s *symbols) linker(pkg docsonnet.Package, parents []string, root bool) *linker {
+	scope := packagePath(pkg, parents, root)
+	return &linker{
+		symbols: s,
+		page:    s.targets[scope].page,
+		scope:   scope,
+	}
+}
+
+// fields returns a copy of `api` with references replaced
+func (l *linker) fields(api docsonnet.Fields, path string) docsonnet.Fields {
+	if api == nil {
+		return nil
+	}
+
+	// the object holding the fields
+	parent := strings.TrimSuffix(path, ".")
+
+	out := make(docsonnet.Fields, len(api))
+	for k, v := range api {
+		switch {
+		case v.Function != nil:
+			fn := *v.Function
+			fn.Help = l.link(fn.Help, "fn "+path+k, parent)
+			if fn.Return != nil {
+				ret := *fn.Return
+				ret.Help = l.link(ret.Help, "fn "+path+k, parent)
+				fn.Return = &ret
+			}
+			v.Function = &fn
+		case v.Object != nil:
+			obj := *v.Object
+			obj.Help = l.link(obj.Help, "obj "+path+k, path+k)
+			obj.Fields = l.fields(obj.Fields, path+k+".")
+			v.Object = &obj
+		case v.Value != nil:
+			val := *v.Value
+			val.Help = l.link(val.Help, path+k, parent)
+			v.Value = &val
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// link replaces the references in `help`, which is the help text of `where`.
+// References are looked up relative to the object at the dotted path `obj`
+// first, see lookupFrom.
+func (l *linker) link(help, where, obj string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range expRefs.FindAllStringSubmatchIndex(help, -1) {
+		b.WriteString(help[last:m[0]])
+		last = m[1]
+
+		ref := ""
+		switch {
+		case m[2] >= 0:
+			ref = help[m[2]:m[3]]
+		case m[4] >= 0:
+			ref = help[m[4]:m[5]]
+		default:
+			// code
+			b.WriteString(help[m[0]:m[1]])
+			continue
+		}
+
+		t, ok := l.lookupFrom(obj, ref)
+		if !ok {
+			l.symbols.unresolved = append(l.symbols.unresolved, fmt.Sprintf("'%s' in %s", ref, where))
+			b.WriteString(help[m[0]:m[1]])
+			continue
+		}
+		b.WriteString(md.Link(md.Code(md.Text(ref)), l.href(t)).String())
+	}
+	b.WriteString(help[last:])
+	return b.String()
+}
+
+// lookup finds the target of `ref`, relative to the scope first
+func (l *linker) lookup(ref string) (target, bool) {
+	return l.lookupFrom("", ref)
+}
+
+// lookupFrom finds the target of `ref`, relative to the object at the dotted
+// path `obj` within the scope and its parent objects first, so fields may
+// refer to their siblings by name
+func (l *linker) lookupFrom(obj, ref string) (target, bool) {
+	for {
+		if t, ok := l.symbols.targets[joinPath(joinPath(l.scope, obj), ref)]; ok {
+			return t, true
+		}
+		if obj == "" {
+			break
+		}
+		obj = parentPath(obj)
+	}
+	t, ok := l.symbols.targets[ref]
+	return t, ok
+}
+
+// parentPath returns the parent of the dotted path `p`, which is empty for top
+// level fields
+func parentPath(p string) string {
+	i := strings.LastIndex(p, ".")
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// typ renders `t` as code, linking the members that name documented objects
+// or packages
+func (l *linker) typ(t docsonnet.Type) md.Elem {
+	if !l.links(t) {
+		return md.Code(md.Text(string(t)))
+	}
+	return md.Text(l.typeExpr(t.Expr()))
+}
+
+// typeExpr renders `t` as code linked to the object or package it names. Unions
+// are rendered member by member, as are unions that are the elements of
+// collections, e.g. `array<` [`a`] | [`b`] `>`.
+func (l *linker) typeExpr(t docsonnet.TypeExpr) string {
+	if t.IsUnion() {
+		out := make([]string, len(t.Union))
+		for i, m := range t.Union {
+			out[i] = l.typeExpr(m)
+		}
+		return strings.Join(out, " | ")
+	}
+
+	code := md.Code(md.Text(t.String()))
+	if target, ok := l.typeTarget(t); ok {
+		return md.Link(code, l.href(target)).String()
+	}
+	if t.Elem != nil && l.linksExpr(*t.Elem) {
+		// spaced, as adjacent code spans would merge
+		return md.Code(md.Text(t.Name+"<")).String() + " " + l.typeExpr(*t.Elem) + " " + md.Code(md.Text(">")).String()
+	}
+	return code.String()
+}
+
+// links reports whether `t` names any documented object or package
+func (l *linker) links(t docsonnet.Type) bool {
+	if l == nil {
+		return false
+	}
+	return l.linksExpr(t.Expr())
+}
+
+func (l *linker) linksExpr(t docsonnet.TypeExpr) bool {
+	switch {
+	case t.IsUnion():
+		for _, m := range t.Union {
+			if l.linksExpr(m) {
+				return true
+			}
+		}
+		return false
+	case t.Elem != nil:
+		return l.linksExpr(*t.Elem)
+	}
+	_, ok := l.typeTarget(t)
+	return ok
+}
+
+// typeTarget returns the documented object or package named by `t`, or the
+// elements of `t` if it is a collection. Unions have no single target, see
+// typeExpr.
+func (l *linker) typeTarget(t docsonnet.TypeExpr) (target, bool) {
+	for t.Elem != nil {
+		t = *t.Elem
+	}
+	if t.IsUnion() || t.Name == "" || docsonnet.SimpleType(t.Name).Valid() {
+		return target{}, false
+	}
+
+	got, ok := l.lookup(t.Name)
+	return got, ok && got.isType
+}
+
+// href returns the link to `t`, relative to the page
+func (l *linker) href(t target) string {
+	link := ""
+	if t.page != l.page {
+		link = relPath(path.Dir(l.page), t.page)
+	}
+	if t.anchor != "" {
+		link += "#" + t.anchor
+	}
+	return link
+}
+
+// relPath returns the slash separated path of `target` relative to the
+// directory `base`. Both are relative to the same root.
+func relPath(base, target string) string {
+	if base == "." {
+		return target
+	}
+
+	from := strings.Split(base, "/")
+	to := strings.Split(target, "/")
+	i := 0
+	for i < len(from) && i < len(to)-1 && from[i] == to[i] {
+		i++
+	}
+	return strings.Repeat("../", len(from)-i) + strings.Join(to[i:], "/")
+}
@@ -0,0 +1,68 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+func TestResolveRefs(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "grafana",
+		Help: "start with [[dashboard.new]], see {@link util}",
+		API: docsonnet.Fields{
+			"dashboard": {Object: &docsonnet.Object{
+				Name: "dashboard",
+				Help: "code like `[[dashboard.new]]` is left alone",
+				Fields: docsonnet.Fields{
+					"new":        {Function: &docsonnet.Function{Name: "new", Help: "add panels using [[util.panel.withTitle]]"}},
+					"withPanels": {Function: &docsonnet.Function{Name: "withPanels", Help: "create the dashboard using [[new]]"}},
+				},
+			}},
+		},
+		Sub: map[string]docsonnet.Package{
+			"util": {
+				Name: "util",
+				Help: "used by {@link dashboard.new}",
+				API: docsonnet.Fields{
+					"panel": {Object: &docsonnet.Object{
+						Name: "panel",
+						Help: "see [[panel.withTitle]]",
+						Fields: docsonnet.Fields{
+							"withTitle": {Function: &docsonnet.Function{Name: "withTitle"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	got, err := ResolveRefs(pkg, Opts{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "start with [`dashboard.new`](#fn-dashboardnew), see [`util`](util.md)", got.Help)
+	assert.Equal(t, "code like `[[dashboard.new]]` is left alone", got.API["dashboard"].Object.Help)
+	assert.Equal(t, "add panels using [`util.panel.withTitle`](util.md#fn-panelwithtitle)", got.API["dashboard"].Object.Fields["new"].Function.Help)
+	// relative to the object holding the field first
+	assert.Equal(t, "create the dashboard using [`new`](#fn-dashboardnew)", got.API["dashboard"].Object.Fields["withPanels"].Function.Help)
+	assert.Equal(t, "used by [`dashboard.new`](README.md#fn-dashboardnew)", got.Sub["util"].Help)
+	// relative to the package first
+	assert.Equal(t, "see [`panel.withTitle`](#fn-panelwithtitle)", got.Sub["util"].API["panel"].Object.Help)
+
+	// the input is not modified
+	assert.Equal(t, "see [[panel.withTitle]]", pkg.Sub["util"].API["panel"].Object.Help)
+
+	pkg.Help = "see [[dashboard.old]] and {@link nothing}"
+	_, err = ResolveRefs(pkg, Opts{})
+	assert.EqualError(t, err, "unresolved references: 'dashboard.old' in package grafana, 'nothing' in package grafana")
+}
+
+func TestRelPath(t *testing.T) {
+	assert.Equal(t, "a.md", relPath(".", "a.md"))
+	assert.Equal(t, "../README.md", relPath("a", "README.md"))
+	assert.Equal(t, "b.md", relPath("a", "a/b.md"))
+	assert.Equal(t, "../c/d.md", relPath("a/b", "a/c/d.md"))
+}
@@ -1,13 +1,11 @@
 package render
 
 import (
-	"encoding/json"
 	"fmt"
 	"path"
-	"sort"
 	"strings"
+	"text/template"
 
-	"github.com/google/go-jsonnet/formatter"
 	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
 	"github.com/jsonnet-libs/docsonnet/pkg/md"
 	"github.com/jsonnet-libs/docsonnet/pkg/slug"
@@ -15,207 +13,343 @@ import (
 
 type Opts struct {
 	URLPrefix string
+	Defaults  DefaultOpts
+
+	// Slug is the strategy of the markdown engine the pages are published
+	// with, which links to headlines need to match. Defaults to GitHub.
+	Slug slug.Strategy
+	// Anchors adds explicit anchors to the headlines of fields, which links
+	// use instead of the ones created by the markdown engine
+	Anchors AnchorStyle
+
+	// SourceLink returns the URL of the source code at `loc`, which is linked
+	// next to each field that has a location. See SourceLinkTemplate.
+	SourceLink func(loc docsonnet.Location) string
+
+	// SinglePage renders sub packages into the page of their parent, as
+	// sections with nested headlines, and lists all packages below a page in
+	// its table of contents
+	SinglePage bool
+	// SplitThreshold keeps sub packages that have more than this many fields
+	// on a page of their own, if SinglePage is set. Zero puts all packages onto
+	// a single page.
+	SplitThreshold int
+
+	// Order lists the fields of objects, in both the index and the sections.
+	// Uses OrderDefault if nil.
+	Order FieldOrder
+
+	// Nav writes the navigation files of a site generator next to the pages
+	Nav NavStyle
+
+	// Frontmatter configures the frontmatter of the pages
+	Frontmatter FrontmatterOpts
+
+	// Templates render the parts of the pages, see ParseTemplates. Uses
+	// DefaultTemplates if nil.
+	Templates *template.Template
 }
 
-func Render(pkg docsonnet.Package, opts Opts) map[string]string {
-	return render(pkg, nil, true, opts.URLPrefix)
+// Render returns the markdown pages of `pkg` and its sub packages, by file
+// name. Fails if help texts reference fields that don't exist, or a template
+// fails.
+func Render(pkg docsonnet.Package, opts Opts) (map[string]string, error) {
+	syms := newSymbols(pkg, opts)
+	pkg, err := syms.resolveRefs(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string)
+	if err := render(pkg, nil, true, 1, syms, opts, out); err != nil {
+		return nil, err
+	}
+	if err := nav(pkg, opts, out); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func render(pkg docsonnet.Package, parents []string, root bool, urlPrefix string) map[string]string {
-	link := path.Join("/", urlPrefix, strings.Join(append(parents, pkg.Name), "/"))
-	if root {
-		link = path.Join("/", urlPrefix)
+// Field renders the section of the field `f`, which is found at the dotted
+// `path`
+func Field(path string, f docsonnet.Field, opts Opts) (string, error) {
+	parent, key := "", path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parent, key = path[:i+1], path[i+1:]
 	}
-	if !strings.HasSuffix(link, "/") {
-		link = link + "/"
+
+	api := docsonnet.Fields{key: f}
+	anchors := make(map[string]string)
+	collectAnchors(api, "", parent, opts.Slug.New(), opts, anchors)
+
+	elems, err := renderApi(api, parent, 0, anchors, nil, opts)
+	if err != nil {
+		return "", err
 	}
+	return md.Doc(elems...).String(), nil
+}
 
-	// head
-	elems := []md.Elem{
-		md.Frontmatter(map[string]interface{}{
-			"permalink": link,
-		}),
-		md.Headline(1, strings.Join(append(parents, pkg.Name), ".")),
+// render adds the page of `pkg` to `out`, along with those of its sub packages
+// that have their own. `weight` is the position of `pkg` among its siblings.
+func render(pkg docsonnet.Package, parents []string, root bool, weight int, syms *symbols, opts Opts, out map[string]string) error {
+	data, err := pageData(pkg, parents, root, weight, syms, opts)
+	if err != nil {
+		return err
 	}
-	if pkg.Import != "" {
-		elems = append(elems, md.CodeBlock("jsonnet", fmt.Sprintf(`local %s = import "%s"`, pkg.Name, pkg.Import)))
+	content, err := execute(opts, TemplatePackage, data)
+	if err != nil {
+		return err
 	}
-	elems = append(elems, md.Text(pkg.Help))
+	out[pageFile(pkg, parents, root, opts)] = content
+
+	return renderSubs(pkg, parents, root, syms, opts, out)
+}
 
-	if len(pkg.Sub) > 0 {
-		keys := make([]string, 0, len(pkg.Sub))
-		for _, s := range pkg.Sub {
-			keys = append(keys, s.Name)
+// renderSubs adds the pages of the sub packages of `pkg` to `out`, including
+// those below sub packages that are rendered into the page of `pkg`
+func renderSubs(pkg docsonnet.Package, parents []string, root bool, syms *symbols, opts Opts, out map[string]string) error {
+	path := subParents(pkg, parents, root)
+	for i, name := range subNames(pkg) {
+		sub := pkg.Sub[name]
+
+		var err error
+		if ownPage(sub, opts) {
+			err = render(sub, path, false, i+1, syms, opts, out)
+		} else {
+			err = renderSubs(sub, path, false, syms, opts, out)
+		}
+		if err != nil {
+			return err
 		}
-		sort.Strings(keys)
+	}
+	return nil
+}
+
+// pageData returns the data the page of `pkg` is rendered with. Sub packages
+// that don't have a page of their own are rendered into its Sections.
+func pageData(pkg docsonnet.Package, parents []string, root bool, weight int, syms *symbols, opts Opts) (PageData, error) {
+	link := path.Join("/", opts.URLPrefix, strings.Join(append(parents, pkg.Name), "/"))
+	if root {
+		link = path.Join("/", opts.URLPrefix)
+	}
+	if !strings.HasSuffix(link, "/") {
+		link = link + "/"
+	}
 
-		var items []md.Elem
-		for _, k := range keys {
-			s := pkg.Sub[k]
+	// inlined packages have no import path of their own, and the one of
+	// packages without any can't be used
+	inline := !root && !ownPage(pkg, opts)
+	if inline || pkg.Import == "" {
+		pkg.Import = ""
+		pkg.Help = stripUsage(pkg.Help)
+	}
 
-			link := s.Name + ".md"
-			if len(s.Sub) > 0 {
-				link = s.Name + "/index.md"
-			}
-			items = append(items, md.Link(md.Text(s.Name), link))
-		}
+	l := syms.linker(pkg, parents, root)
+	data := PageData{
+		context:     context{l: l, opts: opts},
+		Package:     pkg,
+		Title:       pageTitle(pkg, parents),
+		Permalink:   link,
+		Description: firstSentence(pkg.Help),
+		Weight:      weight,
+		Inline:      inline,
+		Subs:        subData(pkg, l.scope, l, opts.SinglePage),
+	}
 
-		elems = append(elems, md.List(items...))
+	// the table of contents of the page covers inlined packages
+	if len(data.Subs) > 0 && !data.Inline {
+		data.Contents = md.List(contents(data.Subs)...).String()
 	}
 
 	// fields of this package
 	if len(pkg.API) > 0 {
-		// index
-		elems = append(elems,
-			md.Headline(2, "Index"),
-			md.List(renderIndex(pkg.API, "", slug.New())...),
-		)
+		anchors := syms.anchors[l.scope]
 
-		// api
-		elems = append(elems, md.Headline(2, "Fields"))
-		elems = append(elems, renderApi(pkg.API, "")...)
-	}
+		index, err := renderIndex(pkg.API, "", anchors, l, opts)
+		if err != nil {
+			return PageData{}, err
+		}
+		data.Index = md.List(index...).String()
 
-	content := md.Doc(elems...).String()
-	key := strings.Join(append(parents, pkg.Name+".md"), "/")
-	if len(pkg.Sub) > 0 {
-		key = strings.Join(append(parents, pkg.Name+"/index.md"), "/")
-	}
-	if root {
-		key = "README.md"
-	}
-	out := map[string]string{
-		key: content,
+		api, err := renderApi(pkg.API, "", 0, anchors, l, opts)
+		if err != nil {
+			return PageData{}, err
+		}
+		data.Fields = md.Doc(api...).String()
 	}
 
-	if len(pkg.Sub) != 0 {
-		for _, s := range pkg.Sub {
-			path := append(parents, pkg.Name)
-			if root {
-				path = parents
-			}
-			got := render(s, path, false, urlPrefix)
-			for k, v := range got {
-				out[k] = v
-			}
+	for i, name := range subNames(pkg) {
+		sub := pkg.Sub[name]
+		if ownPage(sub, opts) {
+			continue
+		}
+
+		sd, err := pageData(sub, subParents(pkg, parents, root), false, i+1, syms, opts)
+		if err != nil {
+			return PageData{}, err
+		}
+		section, err := execute(opts, TemplatePackage, sd)
+		if err != nil {
+			return PageData{}, err
 		}
+		data.Sections = append(data.Sections, nestHeadlines(section))
 	}
 
-	return out
+	return data, nil
 }
 
-func renderIndex(api docsonnet.Fields, path string, s *slug.Slugger) []md.Elem {
-	var elems []md.Elem
-	for _, k := range sortFields(api) {
-		v := api[k]
-		switch {
-		case v.Function != nil:
-			fn := v.Function
-			name := md.Text(fmt.Sprintf("fn %s(%s)", fn.Name, renderParams(fn.Args)))
-			link := "#" + s.Slug("fn "+path+fn.Name)
-			elems = append(elems, md.Link(md.Code(name), link))
-		case v.Object != nil:
-			obj := v.Object
-			name := md.Text("obj " + path + obj.Name)
-			link := "#" + s.Slug("obj "+path+obj.Name)
-			elems = append(elems, md.Link(md.Code(name), link))
-			elems = append(elems, md.List(renderIndex(obj.Fields, path+obj.Name+".", s)...))
-		case v.Value != nil:
-			val := v.Value
-			name := md.Text(fmt.Sprintf("%s %s%s", val.Type, path, val.Name))
-			link := "#" + s.Slug(name.String())
-			elems = append(elems, md.Link(md.Code(name), link))
-		}
-	}
-	return elems
+// subData returns the sub packages of the package at the dotted path `prefix`,
+// linked from the page of `l`. If `deep` is set, their sub packages are
+// included as well.
+func subData(pkg docsonnet.Package, prefix string, l *linker, deep bool) []SubData {
+	var subs []SubData
+	for _, name := range subNames(pkg) {
+		path := joinPath(prefix, name)
+		s := SubData{Name: name, Link: l.href(l.symbols.targets[path])}
+		if deep {
+			s.Subs = subData(pkg.Sub[name], path, l, deep)
+		}
+		subs = append(subs, s)
+	}
+	return subs
 }
 
-func renderApi(api docsonnet.Fields, path string) []md.Elem {
-	var elems []md.Elem
-
-	for _, k := range sortFields(api) {
-		v := api[k]
-		switch {
-		case v.Function != nil:
-			fn := v.Function
-			elems = append(elems,
-				md.Headline(3, fmt.Sprintf("fn %s%s", path, fn.Name)),
-				md.CodeBlock("ts", fmt.Sprintf("%s(%s)", fn.Name, renderParams(fn.Args))),
-				md.Text(fn.Help),
-			)
-		case v.Object != nil:
-			obj := v.Object
-			elems = append(elems,
-				md.Headline(2, fmt.Sprintf("obj %s%s", path, obj.Name)),
-				md.Text(obj.Help),
-			)
-			elems = append(elems, renderApi(obj.Fields, path+obj.Name+".")...)
-
-		case v.Value != nil:
-			val := v.Value
-			elems = append(elems,
-				md.Headline(3, fmt.Sprintf("%s %s%s", val.Type, path, val.Name)),
-			)
-
-			if val.Default != nil {
-				elems = append(elems, md.Paragraph(
-					md.Italic(md.Text("Default value: ")),
-					md.Code(md.Text(fmt.Sprint(val.Default))),
-				))
-			}
-
-			elems = append(elems,
-				md.Text(val.Help),
-			)
+// contents returns the items of the list linking to `subs`
+func contents(subs []SubData) []md.Elem {
+	var items []md.Elem
+	for _, s := range subs {
+		items = append(items, md.Link(md.Text(s.Name), s.Link))
+		if len(s.Subs) > 0 {
+			items = append(items, md.List(contents(s.Subs)...))
 		}
 	}
-
-	return elems
+	return items
 }
 
-func sortFields(api docsonnet.Fields) []string {
-	keys := make([]string, 0, len(api))
-	for k := range api {
-		keys = append(keys, k)
+// pageFile returns the file the page of `pkg` is written to
+func pageFile(pkg docsonnet.Package, parents []string, root bool, opts Opts) string {
+	index := "index.md"
+	if opts.Nav == NavHugo {
+		index = "_index.md"
 	}
 
-	aFn := func(a, b string) bool {
-		return api[a].Function != nil && api[b].Function == nil
+	switch {
+	case root && opts.Nav == NavHugo:
+		return index
+	case root:
+		return "README.md"
+	case hasSubPages(pkg, opts):
+		return strings.Join(append(parents, pkg.Name+"/"+index), "/")
+	default:
+		return strings.Join(append(parents, pkg.Name+".md"), "/")
 	}
-	aNew := func(a, b string) bool {
-		a = strings.ToLower(a)
-		b = strings.ToLower(b)
+}
 
-		return strings.HasPrefix(a, "new") && !strings.HasPrefix(b, "new")
-	}
+// renderIndex renders the entries of the index of the fields in `api`, linking
+// to `anchors`. The entries of categorized fields are nested below the entry of
+// their category.
+func renderIndex(api docsonnet.Fields, path string, anchors map[string]string, l *linker, opts Opts) ([]md.Elem, error) {
+	var elems []md.Elem
+	for _, c := range categorize(api, opts) {
+		var entries []md.Elem
+		for _, k := range c.keys {
+			v := api[k]
+			if v.Function == nil && v.Object == nil && v.Value == nil {
+				continue
+			}
+
+			entry, err := execute(opts, TemplateIndex, newFieldData(context{l: l, opts: opts}, v, path, k, anchors[path+k], 0))
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, md.Text(entry))
+
+			if v.Object != nil {
+				children, err := renderIndex(v.Object.Fields, path+v.Object.Name+".", anchors, l, opts)
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, md.List(children...))
+			}
+		}
 
-	sort.Slice(keys, func(i, j int) bool {
-		a, b := keys[i], keys[j]
+		if c.name == "" || len(entries) == 0 {
+			elems = append(elems, entries...)
+			continue
+		}
 
-		if aNew(a, b) {
-			return true
-		} else if aNew(b, a) {
-			return false
+		entry, err := execute(opts, TemplateCategory, CategoryData{context: context{l: l, opts: opts}, Name: c.name, Parent: path, Index: true})
+		if err != nil {
+			return nil, err
 		}
+		elems = append(elems, md.Text(entry), md.List(entries...))
+	}
+	return elems, nil
+}
+
+// renderApi renders the sections of the fields in `api`, whose headlines have
+// `anchors` and are nested `depth` levels below their usual one. Categorized
+// fields follow the headline of their category, nested one level further.
+// Types naming documented objects are linked using `l`, unless it is nil.
+func renderApi(api docsonnet.Fields, path string, depth int, anchors map[string]string, l *linker, opts Opts) ([]md.Elem, error) {
+	var elems []md.Elem
 
-		if aFn(a, b) {
-			return true
-		} else if aFn(b, a) {
-			return false
+	for _, c := range categorize(api, opts) {
+		depth := depth
+		if c.name != "" {
+			headline, err := execute(opts, TemplateCategory, CategoryData{context: context{l: l, opts: opts}, Name: c.name, Parent: path, Level: 3 + depth})
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, md.Text(headline))
+			depth++
 		}
 
-		return a < b
-	})
+		for _, k := range c.keys {
+			v := api[k]
+
+			name := TemplateValue
+			switch {
+			case v.Function != nil:
+				name = TemplateFunction
+			case v.Object != nil:
+				name = TemplateObject
+			case v.Value == nil:
+				continue
+			}
+
+			// objects have headlines one level above functions and values,
+			// which would put them next to the one of their category
+			depth := depth
+			if v.Object != nil && c.name != "" {
+				depth++
+			}
+
+			section, err := execute(opts, name, newFieldData(context{l: l, opts: opts}, v, path, k, anchors[path+k], depth))
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, md.Text(section))
+
+			if v.Object != nil {
+				children, err := renderApi(v.Object.Fields, path+v.Object.Name+".", depth, anchors, l, opts)
+				if err != nil {
+					return nil, err
+				}
+				elems = append(elems, children...)
+			}
+		}
+	}
 
-	return keys
+	return elems, nil
 }
 
-func renderParams(a []docsonnet.Argument) string {
+func renderParams(a []docsonnet.Argument, opts DefaultOpts) string {
 	args := make([]string, 0, len(a))
 	for _, a := range a {
 		arg := a.Name
 		if a.Default != nil {
-			arg = fmt.Sprintf("%s=%v", arg, jsonParam(a.Default))
+			arg = fmt.Sprintf("%s=%s", arg, formatDefault(a.Default, opts))
 		}
 		args = append(args, arg)
 	}
@@ -223,22 +357,21 @@ func renderParams(a []docsonnet.Argument) string {
 	return strings.Join(args, ", ")
 }
 
-func jsonParam(i interface{}) string {
-
-	d, err := json.Marshal(i)
-	if err != nil {
-		panic(err)
-	}
-
-	s, err := formatter.Format("(jsonParam)", string(d), formatter.Options{
-		PadObjects:       false,
-		PadArrays:        false,
-		PrettyFieldNames: true,
-		StringStyle:      formatter.StringStyleSingle,
-	})
-	if err != nil {
-		panic(err)
+func renderArgs(a []docsonnet.Argument, l *linker) []md.Elem {
+	items := make([]md.Elem, 0, len(a))
+	for _, a := range a {
+		elems := []md.Elem{md.Bold(md.Text(a.Name))}
+		if a.Type != "" {
+			elems = append(elems, md.Text(fmt.Sprintf("(%s)", l.typ(a.Type))))
+		}
+		if len(a.Enums) > 0 {
+			enums := make([]string, len(a.Enums))
+			for i, e := range a.Enums {
+				enums[i] = md.Code(md.Text(jsonnetLiteral(e, false))).String()
+			}
+			elems = append(elems, md.Text("- one of "+strings.Join(enums, ", ")))
+		}
+		items = append(items, md.Paragraph(elems...))
 	}
-
-	return strings.TrimSpace(s)
+	return items
 }
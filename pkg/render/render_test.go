@@ -1,10 +1,14 @@
 package render
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+	"github.com/jsonnet-libs/docsonnet/pkg/slug"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSortFields(t *testing.T) {
@@ -30,11 +34,52 @@ func TestSortFields(t *testing.T) {
 		"metadata",
 	}
 
-	res := sortFields(api)
+	res := sortFields(api, Opts{})
 
 	assert.Equal(t, sorted, res)
 }
 
+func TestRenderReturn(t *testing.T) {
+	api := docsonnet.Fields{
+		"new": {Function: &docsonnet.Function{
+			Name:   "new",
+			Args:   []docsonnet.Argument{{Name: "name", Type: docsonnet.TypeString}},
+			Return: &docsonnet.Return{Type: docsonnet.TypeObject, Help: "the new object"},
+		}},
+		"noop": {Function: &docsonnet.Function{
+			Name: "noop",
+		}},
+		"untyped": {Function: &docsonnet.Function{
+			Name: "untyped",
+			Args: []docsonnet.Argument{{Name: "x"}},
+		}},
+	}
+
+	elems, err := renderApi(api, "", 0, nil, nil, Opts{})
+	require.NoError(t, err)
+	res := md.Doc(elems...).String()
+
+	assert.Contains(t, res, "```ts\nnew(name): object\n```\n\nPARAMETERS:\n\n* **name** (`string`)")
+	assert.Contains(t, res, "*Returns:* the new object")
+	assert.Contains(t, res, "```ts\nnoop()\n```")
+
+	// the names of arguments are not listed again
+	assert.Contains(t, res, "```ts\nuntyped(x)\n```\n\n")
+	assert.NotContains(t, res, "* **x**")
+}
+
+func TestRenderEnums(t *testing.T) {
+	args := []docsonnet.Argument{
+		{Name: "mode", Type: docsonnet.TypeString, Enums: []interface{}{"a", "b"}},
+		{Name: "replicas", Enums: []interface{}{1.0, 3.0}},
+	}
+
+	res := md.List(renderArgs(args, nil)...).String()
+
+	assert.Contains(t, res, "* **mode** (`string`) - one of `'a'`, `'b'`")
+	assert.Contains(t, res, "* **replicas** - one of `1`, `3`")
+}
+
 func dobj() docsonnet.Field {
 	return docsonnet.Field{
 		Object: &docsonnet.Object{},
@@ -46,3 +91,83 @@ func dfn() docsonnet.Field {
 		Function: &docsonnet.Function{},
 	}
 }
+
+func TestRenderSource(t *testing.T) {
+	link, err := SourceLinkTemplate("https://example.com/{{.File}}#L{{.Line}}")
+	require.NoError(t, err)
+
+	api := docsonnet.Fields{
+		"new": {
+			Function: &docsonnet.Function{Name: "new"},
+			Location: &docsonnet.Location{File: "main.libsonnet", Line: 4, Column: 3},
+		},
+		"replicas": {
+			Value: &docsonnet.Value{Name: "replicas", Type: docsonnet.TypeNumber},
+		},
+	}
+
+	elems, err := renderApi(api, "", 0, nil, nil, Opts{SourceLink: link})
+	require.NoError(t, err)
+	res := md.Doc(elems...).String()
+	assert.Contains(t, res, "### fn new\n\n[Source](https://example.com/main.libsonnet#L4)\n\n```ts")
+	assert.Equal(t, 1, strings.Count(res, "[Source]"))
+
+	_, err = SourceLinkTemplate("{{.File")
+	assert.Error(t, err)
+}
+
+func TestRenderTypeLinks(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "grafana",
+		API: docsonnet.Fields{
+			"panel": {Object: &docsonnet.Object{Name: "panel"}},
+			"new": {Function: &docsonnet.Function{
+				Name: "new",
+				Args: []docsonnet.Argument{
+					{Name: "panels", Type: "panel | array<panel> | null"},
+					{Name: "title", Type: docsonnet.TypeString},
+					{Name: "targets", Type: "array<panel | util.target | string>"},
+				},
+				Return: &docsonnet.Return{Type: "util.target", Help: "the dashboard"},
+			}},
+			"target": {Value: &docsonnet.Value{Name: "target", Type: "util.target"}},
+		},
+		Sub: map[string]docsonnet.Package{
+			"util": {Name: "util", API: docsonnet.Fields{
+				"target": {Object: &docsonnet.Object{Name: "target"}},
+			}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{})
+	require.NoError(t, err)
+	res := pages["README.md"]
+
+	assert.Contains(t, res, "* **panels** ([`panel`](#obj-panel) | [`array<panel>`](#obj-panel) | `null`)")
+	assert.Contains(t, res, "* **title** (`string`)")
+	assert.Contains(t, res, "* **targets** (`array<` [`panel`](#obj-panel) | [`util.target`](util.md#obj-target) | `string` `>`)")
+	assert.Contains(t, res, "*Returns:* [`util.target`](util.md#obj-target) - the dashboard")
+	assert.Contains(t, res, "### util.target target\n\n*Type:* [`util.target`](util.md#obj-target)")
+}
+
+func TestRenderSlugStrategy(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "example",
+		Help: "see [[config.replicas]]",
+		API: docsonnet.Fields{
+			"config": {Object: &docsonnet.Object{Name: "config", Fields: docsonnet.Fields{
+				"replicas": {Value: &docsonnet.Value{Name: "replicas", Type: "number | string"}},
+			}}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{})
+	require.NoError(t, err)
+	assert.Contains(t, pages["README.md"], "see [`config.replicas`](#number--string-configreplicas)")
+	assert.Contains(t, pages["README.md"], "* [`number | string config.replicas`](#number--string-configreplicas)")
+
+	pages, err = Render(pkg, Opts{Slug: slug.GitLab})
+	require.NoError(t, err)
+	assert.Contains(t, pages["README.md"], "see [`config.replicas`](#number-string-configreplicas)")
+	assert.Contains(t, pages["README.md"], "* [`number | string config.replicas`](#number-string-configreplicas)")
+}
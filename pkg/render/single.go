@@ -0,0 +1,71 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// ownPage reports whether the sub package `pkg` is rendered to a page of its
+// own, instead of into the page of its parent
+func ownPage(pkg docsonnet.Package, opts Opts) bool {
+	if !opts.SinglePage {
+		return true
+	}
+	return opts.SplitThreshold > 0 && countFields(pkg.API) > opts.SplitThreshold
+}
+
+// hasSubPages reports whether any package below `pkg` has a page of its own,
+// which are written to the directory of `pkg`
+func hasSubPages(pkg docsonnet.Package, opts Opts) bool {
+	for _, s := range pkg.Sub {
+		if ownPage(s, opts) || hasSubPages(s, opts) {
+			return true
+		}
+	}
+	return false
+}
+
+// countFields returns the number of fields in `api`, including those of
+// objects
+func countFields(api docsonnet.Fields) int {
+	n := 0
+	for _, f := range api {
+		n++
+		if f.Object != nil {
+			n += countFields(f.Object.Fields)
+		}
+	}
+	return n
+}
+
+// expHeadline matches the start of an ATX headline
+var expHeadline = regexp.MustCompile(`^#{1,6}(\s|$)`)
+
+// nestHeadlines increases the level of the headlines in `s` by one, leaving
+// code blocks alone. Headlines at maxLevel stay there.
+func nestHeadlines(s string) string {
+	deepest := strings.Repeat("#", maxLevel)
+	lines := strings.Split(s, "\n")
+	fenced := false
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "```"):
+			fenced = !fenced
+		case !fenced && expHeadline.MatchString(l) && !strings.HasPrefix(l, deepest):
+			lines[i] = "#" + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// expUsage matches the install and usage sections doc-util appends to the help
+// of packages created using `d.pkg`
+var expUsage = regexp.MustCompile("\n## (Install|Usage)\n\n```(jsonnet)?\n.*\n```\n")
+
+// stripUsage removes the install and usage sections from the help of a
+// package, for packages that can't be imported on their own
+func stripUsage(help string) string {
+	return expUsage.ReplaceAllString(help, "")
+}
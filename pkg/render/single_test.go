@@ -0,0 +1,146 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestHeadlines(t *testing.T) {
+	in := "# title\n\ntext #1\n\n```\n# comment\n```\n\n## sub\n#hashtag"
+	want := "## title\n\ntext #1\n\n```\n# comment\n```\n\n### sub\n#hashtag"
+	assert.Equal(t, want, nestHeadlines(in))
+
+	// there are no headlines below maxLevel
+	assert.Equal(t, "###### deepest", nestHeadlines("###### deepest"))
+}
+
+// singlePackage has fields named alike in the root and a sub package, and a
+// sub package with more fields than the others
+var singlePackage = docsonnet.Package{
+	Name: "lib",
+	API: docsonnet.Fields{
+		"new": {Function: &docsonnet.Function{Name: "new", Help: "see [[small.new]]"}},
+	},
+	Sub: map[string]docsonnet.Package{
+		"small": {
+			Name: "small",
+			API: docsonnet.Fields{
+				"new": {Function: &docsonnet.Function{Name: "new", Help: "see [[big.a]]"}},
+			},
+			Sub: map[string]docsonnet.Package{
+				"nested": {Name: "nested", Help: "nested package"},
+			},
+		},
+		"big": {
+			Name: "big",
+			API: docsonnet.Fields{
+				"a": {Value: &docsonnet.Value{Name: "a", Type: docsonnet.TypeString}},
+				"b": {Value: &docsonnet.Value{Name: "b", Type: docsonnet.TypeString}},
+			},
+		},
+	},
+}
+
+func TestSinglePage(t *testing.T) {
+	pages, err := Render(singlePackage, Opts{SinglePage: true})
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+
+	page := pages["README.md"]
+
+	// table of contents of all packages
+	assert.Contains(t, page, "* [big](#big)\n* [small](#small)\n  * [nested](#smallnested)")
+
+	// nested sections, without frontmatter
+	assert.Contains(t, page, "\n## big\n\n\n\n### Index")
+	assert.Contains(t, page, "\n## small\n")
+	assert.Contains(t, page, "\n### small.nested\n\nnested package")
+	assert.Equal(t, 1, strings.Count(page, "permalink"))
+
+	// slugs of alike headlines are unique across the page
+	assert.Contains(t, page, "see [`small.new`](#fn-new-1)")
+	assert.Contains(t, page, "* [`fn new()`](#fn-new-1)")
+	assert.Contains(t, page, "see [`big.a`](#string-a)")
+}
+
+func TestSinglePageExplicitAnchors(t *testing.T) {
+	pages, err := Render(singlePackage, Opts{SinglePage: true, Anchors: AnchorsHTML})
+	require.NoError(t, err)
+
+	page := pages["README.md"]
+	assert.Contains(t, page, "<a id=\"fn-new\"></a>\n\n### fn new")
+	assert.Contains(t, page, "<a id=\"fn-small-new\"></a>\n\n#### fn new")
+	assert.Contains(t, page, "see [`small.new`](#fn-small-new)")
+}
+
+func TestSplitThreshold(t *testing.T) {
+	pages, err := Render(singlePackage, Opts{SinglePage: true, SplitThreshold: 1})
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(pages))
+	for k := range pages {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"README.md", "big.md"}, keys)
+
+	page := pages["README.md"]
+	assert.Contains(t, page, "* [big](big.md)\n* [small](#small)\n  * [nested](#smallnested)")
+	assert.Contains(t, page, "see [`big.a`](big.md#string-a)")
+	assert.NotContains(t, page, "## big")
+}
+
+func TestSinglePageNav(t *testing.T) {
+	pages, err := Render(singlePackage, Opts{SinglePage: true, SplitThreshold: 1, Nav: NavMkDocs})
+	require.NoError(t, err)
+
+	assert.Equal(t, "nav:\n- lib: README.md\n- big: big.md\n", pages["mkdocs.yml"])
+}
+
+// usage is the help doc-util creates for a package named `name`, imported
+// from `url`
+func usage(name, url string) string {
+	return "help\n## Install\n\n```\njb install " + url + "@master\n```\n\n## Usage\n\n```jsonnet\nlocal " + name + " = import \"" + url + "\"\n```\n"
+}
+
+func TestSinglePageDeep(t *testing.T) {
+	obj := docsonnet.Fields{
+		"obj": {Object: &docsonnet.Object{Name: "obj", Fields: docsonnet.Fields{
+			"nested": {Object: &docsonnet.Object{Name: "nested", Fields: docsonnet.Fields{
+				"value": {Value: &docsonnet.Value{Name: "value", Type: docsonnet.TypeString}, Group: "group"},
+			}}},
+		}}},
+	}
+	pkg := docsonnet.Package{
+		Name: "lib", Import: "example.com/lib", Help: usage("lib", "example.com/lib"),
+		Sub: map[string]docsonnet.Package{
+			"a": {Name: "a", Import: "example.com/a", Help: usage("a", "example.com/a"), API: obj,
+				Sub: map[string]docsonnet.Package{
+					"b": {Name: "b", Help: usage("b", ""), API: obj,
+						Sub: map[string]docsonnet.Package{
+							"c": {Name: "c", Help: "help", API: obj},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{SinglePage: true})
+	require.NoError(t, err)
+	page := pages["README.md"]
+
+	for _, l := range strings.Split(page, "\n") {
+		assert.False(t, strings.HasPrefix(l, "#######"), l)
+	}
+	assert.Contains(t, page, "\n###### string obj.nested.value\n")
+
+	// only the root package can be imported
+	assert.Equal(t, 1, strings.Count(page, "## Usage"))
+	assert.Contains(t, page, "local lib = import \"example.com/lib\"")
+	assert.NotContains(t, page, "import \"example.com/a\"")
+	assert.NotContains(t, page, "import \"\"")
+}
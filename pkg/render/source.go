@@ -0,0 +1,39 @@
+package render
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+)
+
+// SourceLinkTemplate returns a function for Opts.SourceLink, that executes the
+// text/template `tmpl` with the docsonnet.Location, e.g.
+// `https://github.com/org/repo/blob/main/{{.File}}#L{{.Line}}`
+func SourceLinkTemplate(tmpl string) (func(loc docsonnet.Location) string, error) {
+	t, err := template.New("sourceLink").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(loc docsonnet.Location) string {
+		var s strings.Builder
+		if err := t.Execute(&s, loc); err != nil {
+			return ""
+		}
+		return s.String()
+	}, nil
+}
+
+func renderSource(loc *docsonnet.Location, opts Opts) []md.Elem {
+	if loc == nil || opts.SourceLink == nil {
+		return nil
+	}
+
+	url := opts.SourceLink(*loc)
+	if url == "" {
+		return nil
+	}
+	return []md.Elem{md.Link(md.Text("Source"), url)}
+}
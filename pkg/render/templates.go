@@ -0,0 +1,314 @@
+package render
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/jsonnet-libs/docsonnet/pkg/md"
+)
+
+// Names of the templates the pages are rendered with
+const (
+	// TemplatePackage renders the page of a package, executed with a PageData
+	TemplatePackage = "package"
+	// TemplateIndex renders an entry of the index, executed with a FieldData.
+	// Entries are put into a nested list.
+	TemplateIndex = "index"
+	// TemplateFunction, TemplateObject and TemplateValue render the section of
+	// a field, executed with a FieldData. The sections of the fields of an
+	// object follow its own.
+	TemplateFunction = "function"
+	TemplateObject   = "object"
+	TemplateValue    = "value"
+	// TemplateCategory renders the headline of a category of fields, and its
+	// entry of the index, executed with a CategoryData
+	TemplateCategory = "category"
+)
+
+//go:embed templates/default.md.tmpl
+var defaultTemplates string
+
+// templateFuncs are available in all templates, in addition to the methods of
+// PageData, FieldData and CategoryData
+var templateFuncs = template.FuncMap{
+	// code formats its argument as inline code
+	"code": func(s interface{}) string {
+		return md.Code(md.Text(fmt.Sprint(s))).String()
+	},
+	// jsonnet formats a value as a Jsonnet literal
+	"jsonnet": func(v interface{}) string {
+		return jsonnetLiteral(v, false)
+	},
+	"join": strings.Join,
+}
+
+var defaults = template.Must(newTemplates().Parse(defaultTemplates))
+
+func newTemplates() *template.Template {
+	return template.New("docsonnet").Funcs(templateFuncs).Option("missingkey=error")
+}
+
+// DefaultTemplates returns the templates used if Opts.Templates is nil. They
+// define TemplatePackage, TemplateIndex, TemplateFunction, TemplateObject,
+// TemplateValue and TemplateCategory.
+func DefaultTemplates() *template.Template {
+	return template.Must(defaults.Clone())
+}
+
+// ParseTemplates parses the text/template files at `paths` on top of the
+// default templates, so they may redefine any of them using
+// `{{ define "function" }}...{{ end }}`
+func ParseTemplates(paths ...string) (*template.Template, error) {
+	t := DefaultTemplates()
+	for _, p := range paths {
+		if _, err := t.ParseFiles(p); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (o Opts) templates() *template.Template {
+	if o.Templates != nil {
+		return o.Templates
+	}
+	return defaults
+}
+
+func execute(opts Opts, name string, data interface{}) (string, error) {
+	var s strings.Builder
+	if err := opts.templates().ExecuteTemplate(&s, name, data); err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+// context holds what the methods common to PageData and FieldData need
+type context struct {
+	l    *linker
+	opts Opts
+}
+
+// Link returns the link to the field or package at the dotted path `ref`,
+// resolved like references in help texts
+func (c context) Link(ref string) (string, error) {
+	if c.l != nil {
+		if t, ok := c.l.lookup(ref); ok {
+			return c.l.href(t), nil
+		}
+	}
+	return "", fmt.Errorf("unresolved reference '%s'", ref)
+}
+
+// Linked reports whether `t` names documented objects, that Type links to
+func (c context) Linked(t docsonnet.Type) bool {
+	return c.l.links(t)
+}
+
+// Type renders `t` as inline code, linking names of documented objects
+func (c context) Type(t docsonnet.Type) string {
+	return c.l.typ(t).String()
+}
+
+// Slug returns the anchor the markdown engine creates for the headline `text`,
+// ignoring duplicates
+func (c context) Slug(text string) string {
+	return c.opts.Slug.New().Slug(text)
+}
+
+// PageData is the data TemplatePackage is executed with
+type PageData struct {
+	context
+
+	Package docsonnet.Package
+	// Title is the dotted name of the package, including its parents
+	Title string
+	// Permalink is the URL of the page
+	Permalink string
+	// Description is the first sentence of the help of the package
+	Description string
+	// Weight is the position of the package among its siblings, sorted by
+	// name, starting at 1
+	Weight int
+	// Inline is set if the package is rendered into the page of its parent,
+	// see Opts.SinglePage
+	Inline bool
+	// Subs are the sub packages, sorted by name
+	Subs []SubData
+	// Contents is the rendered list of Subs
+	Contents string
+	// Index is the rendered index of the fields
+	Index string
+	// Fields are the rendered sections of the fields
+	Fields string
+	// Sections are the rendered sub packages that don't have a page of their
+	// own, with nested headlines
+	Sections []string
+}
+
+// Frontmatter returns the frontmatter of the page, including the delimiters.
+// Empty if disabled, see FrontmatterOpts, or if the package is Inline.
+func (p PageData) Frontmatter() (string, error) {
+	if p.Inline {
+		return "", nil
+	}
+	return frontmatter(p)
+}
+
+// SubData is a sub package of a page
+type SubData struct {
+	Name string
+	// Link is the path of its page relative to the page of the parent, or the
+	// anchor of its section
+	Link string
+	// Subs are its own sub packages, if all packages below a page are listed,
+	// see Opts.SinglePage
+	Subs []SubData
+}
+
+// FieldData is the data the templates of fields are executed with. Exactly one
+// of Function, Object and Value is set.
+type FieldData struct {
+	context
+
+	Field    docsonnet.Field
+	Function *docsonnet.Function
+	Object   *docsonnet.Object
+	Value    *docsonnet.Value
+	// Runtime describes the field as found during evaluation, if known
+	Runtime *docsonnet.Runtime
+
+	// Parent is the dotted path of the object holding the field, with a
+	// trailing dot. Empty for fields of the package itself.
+	Parent string
+	// Path is the dotted path of the field
+	Path string
+	// Anchor of the headline of the field
+	Anchor string
+
+	// depth is the number of levels the headline is nested below its usual
+	// one, e.g. by a category
+	depth int
+}
+
+func newFieldData(c context, f docsonnet.Field, parent, key, anchor string, depth int) FieldData {
+	return FieldData{
+		context:  c,
+		Field:    f,
+		Function: f.Function,
+		Object:   f.Object,
+		Value:    f.Value,
+		Runtime:  f.Runtime,
+		Parent:   parent,
+		Path:     parent + key,
+		Anchor:   anchor,
+		depth:    depth,
+	}
+}
+
+// Headline returns the headline `text` of the given level, along with the
+// explicit anchor of the field if enabled. Fields of a category are nested one
+// level below the given one.
+func (f FieldData) Headline(level int, text string) string {
+	id := f.Anchor
+	if id == "" {
+		kind := kindValue
+		switch {
+		case f.Function != nil:
+			kind = kindFn
+		case f.Object != nil:
+			kind = kindObj
+		}
+		id = anchorID(kind, f.Path)
+	}
+	return md.Doc(headline(level+f.depth, text, id, f.opts)...).String()
+}
+
+// Visibility labels fields declared unusually, e.g. "hidden (`::`)": values
+// that are hidden, so not part of the output, and fields that are forced
+// visible. Empty otherwise, and if unknown.
+func (f FieldData) Visibility() string {
+	if f.Runtime == nil {
+		return ""
+	}
+
+	switch f.Runtime.Visibility {
+	case docsonnet.VisibilityHidden:
+		if f.Value != nil {
+			return "hidden (`::`)"
+		}
+	case docsonnet.VisibilityForced:
+		return "forced visible (`:::`)"
+	}
+	return ""
+}
+
+// Source returns the link to the source of the field, if known
+func (f FieldData) Source() string {
+	return md.Doc(renderSource(f.Field.Location, f.opts)...).String()
+}
+
+// Args returns the arguments of a function as in its signature, e.g.
+// `name, replicas=1`
+func (f FieldData) Args() string {
+	if f.Function == nil {
+		return ""
+	}
+	return renderParams(f.Function.Args, f.opts.Defaults)
+}
+
+// ArgsList returns the list describing the arguments of a function. Empty if
+// none of them has a type or enums, as the list would only repeat their names.
+func (f FieldData) ArgsList() string {
+	if f.Function == nil {
+		return ""
+	}
+
+	described := false
+	for _, a := range f.Function.Args {
+		if a.Type != "" || len(a.Enums) > 0 {
+			described = true
+		}
+	}
+	if !described {
+		return ""
+	}
+	return md.List(renderArgs(f.Function.Args, f.l)...).String()
+}
+
+// Default returns the default of a value, inline or as a code block depending
+// on its length
+func (f FieldData) Default() string {
+	if f.Value == nil || f.Value.Default == nil {
+		return ""
+	}
+	return md.Doc(renderDefault(f.Value.Default, f.opts.Defaults)...).String()
+}
+
+// CategoryData is the data TemplateCategory is executed with. Categories are
+// set using `d.group` in docstrings.
+type CategoryData struct {
+	context
+
+	// Name of the category
+	Name string
+	// Parent is the dotted path of the object holding the fields of the
+	// category, with a trailing dot. Empty for fields of the package itself.
+	Parent string
+	// Index is set when rendering the entry of the index, which the entries of
+	// the fields are nested below
+	Index bool
+	// Level of the headline, one above the one of the fields
+	Level int
+}
+
+// Headline returns the headline of the category
+func (c CategoryData) Headline() string {
+	if c.Level > maxLevel {
+		return md.Headline(maxLevel, c.Name).String()
+	}
+	return md.Headline(c.Level, c.Name).String()
+}
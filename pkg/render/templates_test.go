@@ -0,0 +1,110 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplate(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "custom.md.tmpl")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+func TestDefaultTemplates(t *testing.T) {
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"new": {Function: &docsonnet.Function{Name: "new", Help: "creates a lib"}},
+		},
+	}
+
+	want, err := Render(pkg, Opts{})
+	require.NoError(t, err)
+	got, err := Render(pkg, Opts{Templates: DefaultTemplates()})
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+}
+
+func TestVisibility(t *testing.T) {
+	runtime := func(f docsonnet.Field, v docsonnet.Visibility) docsonnet.Field {
+		f.Runtime = &docsonnet.Runtime{Visibility: v}
+		return f
+	}
+
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"new":      runtime(docsonnet.Field{Function: &docsonnet.Function{Name: "new"}}, docsonnet.VisibilityHidden),
+			"mixin":    runtime(docsonnet.Field{Object: &docsonnet.Object{Name: "mixin"}}, docsonnet.VisibilityHidden),
+			"replicas": runtime(docsonnet.Field{Value: &docsonnet.Value{Name: "replicas", Type: docsonnet.TypeNumber}}, docsonnet.VisibilityVisible),
+			"defaults": runtime(docsonnet.Field{Value: &docsonnet.Value{Name: "defaults", Type: docsonnet.TypeObject}}, docsonnet.VisibilityHidden),
+			"kind":     runtime(docsonnet.Field{Value: &docsonnet.Value{Name: "kind", Type: docsonnet.TypeString}}, docsonnet.VisibilityForced),
+			"unknown":  {Value: &docsonnet.Value{Name: "unknown", Type: docsonnet.TypeString}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{})
+	require.NoError(t, err)
+	page := pages["README.md"]
+
+	assert.Contains(t, page, "### fn new\n\n```ts")
+	// only unusual declarations are labelled
+	assert.Contains(t, page, "## obj mixin\n\n")
+	assert.NotContains(t, page, "## obj mixin\n\n*Visibility:*")
+	assert.Contains(t, page, "### number replicas\n\n")
+	assert.NotContains(t, page, "### number replicas\n\n*Visibility:*")
+	assert.Contains(t, page, "### object defaults\n\n*Visibility:* hidden (`::`)")
+	assert.Contains(t, page, "### string kind\n\n*Visibility:* forced visible (`:::`)")
+	assert.Contains(t, page, "### string unknown\n\n")
+	assert.NotContains(t, page, "### string unknown\n\n*Visibility:*")
+}
+
+func TestParseTemplates(t *testing.T) {
+	file := writeTemplate(t, `{{ define "function" }}### {{ .Path }}
+
+See [the object]({{ .Link "obj" }}). {{ .Function.Help }}{{ end }}`)
+
+	templates, err := ParseTemplates(file)
+	require.NoError(t, err)
+
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"new": {Function: &docsonnet.Function{Name: "new", Help: "creates a lib"}},
+			"obj": {Object: &docsonnet.Object{Name: "obj", Help: "an object"}},
+		},
+	}
+
+	pages, err := Render(pkg, Opts{Templates: templates})
+	require.NoError(t, err)
+
+	page := pages["README.md"]
+	assert.Contains(t, page, "### new\n\nSee [the object](#obj-obj). creates a lib")
+	// templates that aren't redefined are kept
+	assert.Contains(t, page, "## obj obj\n\nan object")
+}
+
+func TestParseTemplatesErrors(t *testing.T) {
+	_, err := ParseTemplates(writeTemplate(t, `{{ define "function" }}{{ .Path }`))
+	assert.Error(t, err)
+
+	templates, err := ParseTemplates(writeTemplate(t, `{{ define "function" }}{{ .Link "missing" }}{{ end }}`))
+	require.NoError(t, err)
+
+	pkg := docsonnet.Package{
+		Name: "lib",
+		API: docsonnet.Fields{
+			"new": {Function: &docsonnet.Function{Name: "new"}},
+		},
+	}
+	_, err = Render(pkg, Opts{Templates: templates})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unresolved reference 'missing'")
+}
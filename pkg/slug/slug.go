@@ -6,8 +6,14 @@ import (
 	"strings"
 )
 
+// Slugger creates anchors for headlines, numbering duplicates
 type Slugger struct {
 	occurences map[string]int
+
+	// slug converts a headline, if the strategy is not GitHub
+	slug func(string) string
+	// sep is put in front of the number of duplicates
+	sep string
 }
 
 var (
@@ -15,6 +21,7 @@ var (
 	expSpecials   = regexp.MustCompile("[\u2000-\u206F\u2E00-\u2E7F\\'!\"#$%&()*+,./:;<=>?@[\\]^`{|}~’]")
 )
 
+// New returns a Slugger using the GitHub strategy
 func New() *Slugger {
 	return &Slugger{
 		occurences: make(map[string]int),
@@ -22,6 +29,10 @@ func New() *Slugger {
 }
 
 func (s *Slugger) Slug(str string) string {
+	if s.slug != nil {
+		return s.unique(s.slug(str))
+	}
+
 	str = expWhitespace.ReplaceAllString(str, "-")
 	str = expSpecials.ReplaceAllString(str, "")
 
@@ -33,3 +44,14 @@ func (s *Slugger) Slug(str string) string {
 
 	return strings.ToLower(str)
 }
+
+// unique numbers `slug` if it was returned before
+func (s *Slugger) unique(slug string) string {
+	out := slug
+	for s.occurences[out] > 0 {
+		s.occurences[slug]++
+		out = slug + s.sep + strconv.Itoa(s.occurences[slug]-1)
+	}
+	s.occurences[out]++
+	return out
+}
@@ -0,0 +1,127 @@
+package slug
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Strategy is the way a markdown engine derives the anchors of headlines. The
+// zero value is GitHub.
+type Strategy int
+
+const (
+	// GitHub, also used by Docusaurus
+	GitHub Strategy = iota
+	// GitLab additionally collapses consecutive hyphens
+	GitLab
+	// Hugo using Goldmark with the default autoHeadingIDType `github`
+	Hugo
+	// MkDocs using the toc extension of Python-Markdown
+	MkDocs
+	// Docusaurus uses github-slugger, like GitHub
+	Docusaurus
+)
+
+var strategyNames = map[Strategy]string{
+	GitHub:     "github",
+	GitLab:     "gitlab",
+	Hugo:       "hugo",
+	MkDocs:     "mkdocs",
+	Docusaurus: "docusaurus",
+}
+
+// Strategies are the names of all strategies, as understood by ParseStrategy
+func Strategies() []string {
+	out := make([]string, 0, len(strategyNames))
+	for s := GitHub; s <= Docusaurus; s++ {
+		out = append(out, s.String())
+	}
+	return out
+}
+
+// ParseStrategy returns the Strategy called `name`
+func ParseStrategy(name string) (Strategy, error) {
+	for s, n := range strategyNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown slug strategy '%s', expected one of %s", name, strings.Join(Strategies(), ", "))
+}
+
+func (s Strategy) String() string {
+	if n, ok := strategyNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("Strategy(%d)", int(s))
+}
+
+// New returns a Slugger using this strategy
+func (s Strategy) New() *Slugger {
+	sl := New()
+	switch s {
+	case GitLab:
+		sl.slug, sl.sep = gitlab, "-"
+	case Hugo:
+		sl.slug, sl.sep = hugo, "-"
+	case MkDocs:
+		sl.slug, sl.sep = mkdocs, "_"
+	}
+	return sl
+}
+
+var (
+	expGitLabPunctuation = regexp.MustCompile(`[^\p{L}\p{M}\p{N}\p{Pc}\- ]`)
+	expHyphens           = regexp.MustCompile(`-{2,}`)
+)
+
+// gitlab removes all non-word characters except spaces and hyphens, turns
+// spaces into hyphens and collapses consecutive ones
+func gitlab(str string) string {
+	str = strings.ToLower(str)
+	str = expGitLabPunctuation.ReplaceAllString(str, "")
+	str = strings.ReplaceAll(str, " ", "-")
+	return expHyphens.ReplaceAllString(str, "-")
+}
+
+// hugo keeps letters, numbers, hyphens and underscores, and turns whitespace
+// into hyphens
+func hugo(str string) string {
+	var b strings.Builder
+	for _, r := range str {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			b.WriteRune(unicode.ToLower(r))
+		case r == '-' || r == '_':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+var (
+	expMkDocsSpecials = regexp.MustCompile(`[^\w\s-]`)
+	expMkDocsSpaces   = regexp.MustCompile(`[-\s]+`)
+)
+
+// mkdocs removes everything but word characters, whitespace and hyphens, and
+// turns runs of whitespace and hyphens into a single hyphen. Like in
+// Python-Markdown, characters are decomposed (NFKD) first, so the base letter
+// of e.g. `é` is kept, while other non-ASCII characters are dropped.
+func mkdocs(str string) string {
+	str = strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(str))
+	str = expMkDocsSpecials.ReplaceAllString(str, "")
+	str = strings.ToLower(strings.TrimSpace(str))
+	return expMkDocsSpaces.ReplaceAllString(str, "-")
+}
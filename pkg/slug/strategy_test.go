@@ -0,0 +1,79 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategies(t *testing.T) {
+	type slugCase struct {
+		in, out string
+	}
+
+	cases := map[Strategy][]slugCase{
+		GitHub: {
+			{"fn new", "fn-new"},
+			{"fn new", "fn-new-1"},
+			{"obj dashboard.panel", "obj-dashboardpanel"},
+			{"heading with a - dash", "heading-with-a---dash"},
+			{"exchange.bind_headers(exchange, routing [, bindCallback])", "exchangebind_headersexchange-routing--bindcallback"},
+		},
+		// https://docs.gitlab.com/ee/user/markdown.html#heading-ids-and-links
+		GitLab: {
+			{"This heading has spaces in it", "this-heading-has-spaces-in-it"},
+			{"This heading has a あ in it", "this-heading-has-a-あ-in-it"},
+			{"This heading has spaces in it", "this-heading-has-spaces-in-it-1"},
+			{"This heading has spaces in it", "this-heading-has-spaces-in-it-2"},
+			{"heading with a - dash", "heading-with-a-dash"},
+			{"obj dashboard.panel", "obj-dashboardpanel"},
+		},
+		// https://gohugo.io/getting-started/configuration-markup/#autoheadingidtype
+		Hugo: {
+			{"fn new", "fn-new"},
+			{"fn new", "fn-new-1"},
+			{"A√b", "ab"},
+			{"Überblick", "überblick"},
+			{"heading with a - dash", "heading-with-a---dash"},
+			{"string config.replicas", "string-configreplicas"},
+		},
+		// https://python-markdown.github.io/extensions/toc/#slugify
+		MkDocs: {
+			{"Header 2", "header-2"},
+			{"Header 2", "header-2_1"},
+			{"Header 2", "header-2_2"},
+			{"heading with a - dash", "heading-with-a-dash"},
+			{"heading with an _ underscore", "heading-with-an-_-underscore"},
+			{"fn dashboard.new(title, panels=[])", "fn-dashboardnewtitle-panels"},
+			{"Überblick", "uberblick"},
+			{"Ångström ½", "angstrom-12"},
+		},
+		// https://docusaurus.io/docs/markdown-features/toc#heading-ids
+		Docusaurus: {
+			{"Hello World", "hello-world"},
+			{"Hello World", "hello-world-1"},
+			{"obj dashboard.panel", "obj-dashboardpanel"},
+		},
+	}
+
+	for strategy, cs := range cases {
+		t.Run(strategy.String(), func(t *testing.T) {
+			s := strategy.New()
+			for _, c := range cs {
+				assert.Equal(t, c.out, s.Slug(c.in), c.in)
+			}
+		})
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range Strategies() {
+		s, err := ParseStrategy(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, s.String())
+	}
+
+	_, err := ParseStrategy("jekyll")
+	assert.Error(t, err)
+}
@@ -9,4 +9,4 @@ import (
 	"github.com/markbates/pkger/pkging/mem"
 )
 
-var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7a5d93a2baf6f75739c5ede38c80e2b4de35cc8868b733ad3d229cda350501439a403804547ad77cf7a7125eb5dfdc67ef9bf32f2fba21c92259592fbfb5b2cc9f028a77840a933f0588b220773f0312f56920a6fe73df23809238f63336fc15a5c244e80724f2fb999b3a0e08fb0792861daa9e60440949b31f4e1608937727ec094b27f28589103928167ac25702848920f4844727856cc1b39520e9bb283e996045c84bba171cdd3b190884c9bf85cfc21f3d619d39d81726599afb5563e53b94c4c244a0acf52fcf4ffcd8f363504cfed5e11f924f00a3631f6024f4049d4c11f6299b95b1ff1912a1272421f43df6fa472d064ee0c780782886fd27b64c4fd8458cab37a7ee0c444e1aba4ee6d33e9b3a7d7790fd678b447e744a772e784678229f8f6853260eb63826906dedab9ff07db9f90eb16dbb45e653a127001225a94f697f879dccef76c06794f0769c3928f6d33e4634ab3afc237f4b8b2423cd4bdf29672c1b00250167a06a7bdd418f3a6dc307a74d4f561469fca2a38fe2cc4f6307f77defe0a41e3d27c3182519026d4f10399d56f379eac45e9e21fcca10cddd0cfbed40e4296d837dd7698161a7d1dd000d1ce9a4252ba393b622c99df6d99219eec8e9a888e3d3563f09d151e835f6d979ed3b3496ba6dd7a1fe6878d28362272dba3d80eebbcdc0ef4e5e1b7fd34eb89dfa694a52c6e50e3bf02ff906246ebedb3998f4033ff5cfc708c43efbf6e9352b7f31dc77e82524d08f3fa2da913472b2ecdc575f12b616584be032f2bfbe40e2a4f42f91d3cbb949520253e70c70028706089034617b3ba44ef2d630249fa21c67888be06f205fc34ee424f442d2cc097d12f75f250dfcc4f987a6e9ef10cefc73fdbe0edd17c1bb1fb9bef7df058237e868e69133fe12427d06d30cc0b19ff9ef8ff641e47d4cd147318baff81f0e4ee74bbf1fc13e22a4383f53034d76d2a09f54f0044912c2cf28ee174e843fef19f63273ae1e7d908201eb6bcc83854607c36e1748f26e73176594a459b72bf6b32c7580dfed23b47692d6f708176627169d7e92fa3bec830ca3eca49ba218627f87110c4e56a505050ec67dffe8033fdebf3694c73c5eb4c6efd38ce5043d81e70188f411a9a261d91db12cb07cf45d04dbd78cd6ef55248c50e4578f3ec784c4e142e11dffc949e67b498ae2cc7179802b6d83692dc8b2a4f3caffd5d26b3a6b8eab3e96732429e1f9066be7291be14e40281740f99697b89994bb600fe6ce7ed5aee4cbdfa07f4c9a973e2de2cc61924af3382b3756bdf5014f14eb96e7bbdcdeea7623592723114f3f5e8c54227dd14f0bc67e654a344b01e13aa4598a62c8878a18548f76fa4ab3424f6032e9677e9454b9db49bb8c21acb7dc4d1e2340bcce5b3fcf76d2e8b47dc39bd4d931babd1f7b24ed43829d187e2629ec1ffb75761738207064f132aa84e0421a88ca07d4fcc1bcef52ba3ae3798f384ff77e9d29be431784deee7d8a9749e23bc41fec9819b01753f617f9943af0ade94e5c04e6dc053fa44b52722c3e2094fb41e280f01d2ae4c5ce1bc3b4a01524be36caad90fa204ffdbe8b3c94e66f4aab34d8d489294b91de23aa6d944d78095dcce6fba3273cfa346bce7d718e71d9d59cf4caae7be23126277f0a179d81efd9e1b73a8d5e74bed6c93df13e20eb43f239221ea7def82945fc782b7d9686c2efdfbf7bc2aedcc3bb07fe097bfdc4e0bccf4fb818b96d2500c53bc29e9e9f3908f3a9e2f630dfa1ed09143dfbc264a00c473d2162a03119ca227ffdc5d16422c8a22c7e12bf7c92078fb23c91861349f93c504692389224e9ff89f24464d880e82f8fc967e760ea73e4e225097f2f4c46a38178d3138c980893c1cd97f18dcc9a4b8ce25098485c258c85817473d3137e224f9848a228f604bd7dddfefa95389e284cc49eb0f2d8a4624f587758577158ee64288e799380900a939b9e709ba188b1b2f681309194b1a20c1459517ac292b29ec197f170301e7c19feee09f71f90d69bfedd13b4cb49b7bf7ee5714e7d4f98fc5bec893df10fae647664bea868d328fabc7ad396683a142fcb346d21a62db694be50d55a2a959d165bba459492facc91ca3243eb67ff075cafca25188321bcb43cf6a61ffeee099e9339c244f00f041afa716fc9536ae80ff0c75a7db6cd6374774b1686761b1b051819fa430ef42c5edc126868b7d08da699fd28c6b606a8866ea1a1a912880ee3b9ec259e1e4816529e5c59dc03191e40b419ddc9abbd3558eedd48c19e360e5df93ef3b6abc46dbf27b6793cfcd81e6f76d51a86a6425b1f3fdd6d37a2631ea131530b776027b6be7936f46f701dd7f3a9891baf3088ecc0927f423b1a1786ae86aebc7c766525b7b72b78d7cc790badb5ba00f23877a38d687c9b3eacd78cf61edaf226b7b7f3c0d3c785315b116bfb00bded1283427df66673c91aac24106d42435f059efe0d3aa6f2ece99bc0d6d4bd8dd427579632db5444500c177cad59bd97906a9030fe034bce025bfe39fa8ed42fe5be6f634356b05700d617de4576bed0bcdcde02680de6d8daaed8fa81a1331af56099cbd4323d6ccc989c21b4652cdbe6105aba12b8e606de692a744ce9e00ee6a2a5a99b9fe1015aa6121a7a295f4b53457b1b88f3e2004df921b7b6f398ad679912667db6a6fcbc8b972288706e1721b3879a86e937bed3d4f00e3fe460b02a1c5389176bfe4d002240e67280ddd9ed4b9a8d98dc3576b3192c38af3fa931db14ae76808ebea1400bbb3281464933aa9eb4e9d7bcc4ddaa7b103fc4df11b7b9764ce7eb8faa676d5763bef64c2c75a0812766c7f3673232664becea560ef4e993234f637b432b5d789ea151b6afc83395a7bb98dbb4c1e4b7d03cbe074b5313ae97d99230593bfa34b73535720706b45a3de7252d28f73f5353fbb575d7cc4641c39f2bcfff639b4b71fe4c180f7b2b22642e711f483c1d337be0eb30dff04c09bbf12a7123d0f587bd1525d81aac9e0d2d807ea18e2d2dcce745c86552cffffd8940eefbda2d9c174bc6437c810d56733f50431f1f3c1defdd684add59083d198b8ea686f676f904225c8e213570a307684718bbfaeaf9cceeb84d7e607791636ea83dbb6fecaae6ffa55d71bd8fe705a0866e27b6790cc1b3f465b72e6dcbd0c7c889364fded776df35ee7c47e5b3c509b55e77d4cc551c2abf16e16e7d5834fe3be0f6a1d77c7664f8d3d17f426bd61d5f424397f6b68ed9fc85a18f657b3b2f1c93cb5864f4b5ac4abc533188578aa18f23439fe6863ea520da04f62c6c746337fa7e6032725b7facf9065c9e1b6e4b9c2ed9aec3da3f06ce764556d59a8b66af6732d3e8c898d1458da1358fdf5123a7c6dfb8afa1db4646f362c96c98c9a58c2b5135e7f4427f93e7ff79d7df1a1e991f79b06be7ccc681bca1366af6ce7db2896db22db951adb33172108c372c2ee958744c1667ec3d403c9648aeb9c93d9dd967277ee812f6f4606fcfeee14267732d45c71ce7a0e031a1f1c3c5bac2004e73ff2afe331dd9d190cc795b7d627af6aa58c778363af3df69ea1ecc56892b2b81ab1f95261ee89b676b304fcab16185fdd3c21e6caad804a831cdc2bb685ad8058c4bec6dec83c539862509f7b58a86f961c7860210cf037f8dbdc5baf2078e0b4119bfd780d9600022eff9c726f3168f0cef289ccb2def0cd72ef03f3ec777543eebfedd9ae3796587e08963ceb732eebce1774615f732575e25cc6e0152f75eb4294084437b7b0f3d3dc055cc809ebe197a653c67362676657e197675e4d259bfc2a684636e711bdbf126b7065c47f1e20279bcbe6fa6d737f1466572fb78df5ddbb868df5c1fcdbea36901a2a9d2c5e20e6fa4b49d0a4b348ffb771917407a1aef39fda87abe8725918b401343b91ca5cb70c48e1468e898fb1390833d8857a4f40feee390cf559c62881d0d472d6e71df3dc38f567e1fc5eb0aaf79aceee81f32195571fcb95eb7ed7bf8db58d1d5f19da68a9699711e4afdd7b9d88ad86b55f4b72ab30986ada7f8a27f63d81259db0df53455b6cca364af4fb1849f09d6ef638937c3878f68da755acc71e3b01bb3586e42caf50eb09cf3d0f2f755ca3df348178f277ec573dcefa87c767086eff93b2a9f9dfed036edc0338f22f3affabdf547e6e3556ea98127a62fa6ff2ad6301f29e3c5598cabc65fb54f36c7fb71aea34796e7c9b77189350c2341c74ecfe88a2a5741cb4a6f06b71f161f17da7ce3e9983adb55c230a13c3bb1b35af93dcbdf8d994a2d13e7c6b7a43a4f3d402f9a528fc5472d38800867ec7b2fda3c2fd64695ebda813bdbe06e8e69209e63a216afe61fe598d095ade68c53ebd91da8dcae2df348fd7599bf18fa0a037959385bb5b67926b7466f353f06ea62d9bcb477f45efe39e738fc014dbb0e6a70317f25469636569f75cb58d97cfbc35424573f241556d63e769277f178fd955467a643d3cf65f3486a19b5795ae313a4e3bf9d3cf6a13a1b95d82a5be6a1cdd3e2526666019e18a6cc25112e8a532cecc45f3297392ebc8bb1a5dd9c9f8becc0fddaf84eb5efee99e8666f4c1fa0258f734f9f266e7ccfdfc1601530ccace6e6b973e70cc4650d98ed4dbb18aad698333234af7e8f9bd8aa2b926bce317822702e57efa8a951240c8febd8e3c69bcc8a36458d098ea988b6e9f16fabbde49e29217b6b54fbb8855634debbfa2670f9fcf3bd2b1fe2fa7b3ed6ca01b9f298dae6346f796bcf2f73b9ce791bdecabcec918d9531795e9f57f4695ef5e7fe1a34bcf0fde94cdfb7c88d37d4d58c065f1df350edd3768d88d3a16d73e651397e7d47a7794cbd8ff35cef04f3eab3782c2ef8af62a91f676d49abad5bf17ad7bb05664c1cefd2baf2196d5d571e8dc5f7cbcaca27497e94be4c949b8934f83c180e878a341adffc3765e52f4371f8cf959539e77fa9aa2c0e87525dff55c663451e0dc7d2cbaaf2cd581e8fc5f1a02915d77b7e59557e8ff4ef5695af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701af5701ff97ef239d9562da6b48ae3e7eb2cc0374758c8caf6259369e2d9f2c734878a9fa918caaf21375e565e06a6ae06d2171e56378a7a9a9bd0d13e3abd8964a4d0b82c12abc8b97a2b59d8b40e23f911247de2877e5b5a684ffec610e9b6f0c4d1541bcc1edcf521b0ae49fed5c117e7eacaf1fe863e4e02cb5b7d85bac55d1d137797b4de51632be8cd9f1c6d01e72c0f845c1de8a1297adbf5d876d89d63c3edb6b75efea0f6d8992ffbc33a6b67e20d67633bcabfaeaab0ff5cff50fe691723e50480ddd0edcd9122faa329dab8f035b0b0e8e0c92ef4f7509b1bee635957c2d606b5243cfb0bf0ee18f87e6a78fc446b7c18bfdced4d496dedfefdb72abca95df82008853ea967c5143034ff3e236ad7998230b023d8bcda2d3bf11e18f4711ce651cbbd1b8b0d7e54f572e6caf7419fa98daed4f3798c9c1d07164cc96a2ad29cf9e3e2dbcd972eb6c5764c1d73ac079711fbfad370a4d99af3d323afb3666e2b9de12bb299797b6caf92fbf7dcdbe98cca15fa8a51d3c1238d714eacad3f04cbee3735932bebe1f2a3d96fb8a589fab05cc0f68d5575f39a8af9ba8ae7ee03c2dd607e80fc4e4ee3619b379b4e89858a85e9b7f8bfdd9cab4b60699cb52e098c378b10e3f2cd396653dff5ad7bbd6f5ae75bd6b5def5ad7bbd6f5ae75bd6b5def5ad7bbd6f5ae75bd6b5def5ad7bbd6f5ae75bdffa5badeefff0f0000ffff010000ffffe2d72497d44b0000`)))
+var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7cfb6fa33ad3f0bff22a3ff76c80343d9b4aef0f81dd10489bdd262db7578f8ec01043c3ed7049421e9dfffdd31803264dd3eeead1a7ef9295bac1f6608fc733e399f1e07f0f827893e483fb7f0f7050f8a5f30525d1f0354fe2d82bfe0803271fba09aa8b00f42dc806f783619624c5304adc32f4063703254a93acf86917fee0fe13dddc0c9676e40dee07911dc4839bc1b7040dee07839bc1b39d61af68fbc7c9d009e2de8bab2429de8eff6817c81fdcffcfe0cbe05f3783756187dee0bec84a8f16569e9d27f1e07e9043d37fb95eeac5ae17a3eafebf3ec476986ef13003f86c7033909359107a398ce5c0a05f7032b819a0248aecd8cd9b52bc0970fdbc096d4cab83382d8bfa11e65d3fa55becb9f5e3cece08e4bf1a7ad6a35485970f6e065e8c12378831c112ca599664d0b0898ac10d4b749cfc81c2e030446170da90e0d01be2e40f3ad37ef385e9b7a54fbfd112ec73e07958e23e7064675bc72ebc1cbaf3b28b8d0002a489bca80f9726b9177bd91025511a7a85d76fcdd30d3f1aa6b044b03cb0066102682440d714b8b9fe196e82d0a3e53cc0f997edd7fc4b900c2b3b0aa1aac88218e7c07adfbcb45eb57243fa6b560f4569cd2669e6e5f97013da85c756e0635003c4851d00c6619017b4c23b90a7ac4a8ba47d18da5ede155090fa5ed6955db6d1cdedaee021d7ef957a8dae301ef313a6220c83b4085057b309d29cbfe5ba0a7feb6e98526433c07ebaf5ba5210175e16dbe1d049805eef360c1d27b8d09a9f6d44499c17765c1441746e482f2eb224ad863bfe0bf7853b03f0665ea72d7d829f6b1d62145d820803fb520f4e80a3c4bd00807c0f6d2fb4bb99832f34f757fe5c736e5f6a3fe58d33107b3b73f35f011b6e022fbc34e73e77bd6deeb1db9be628bc3ca728dc7a97962c0ef2c2bb34400d30dc047671012abb8844eedbc2f8ee32c0e872f398172e01944e117a17008a30bfd801b45fc000d9c8bfd0bdeba5f910f46092b95ef6011c4acb0f2070e27a4e7981d109d43b6a8082f8767e41149238acceb406511a9ea9ceecf81c0343755904e7dec8abbcff52e48e99429f674f58b4ff62866e9902fb5aeedb7cafd463b13e479d32d029bf1421a3b68a307f43b01ec061cc31d20fa561ba0d0e60b1448ee732960c6bd4d879ccb365c7cebd91705a7377dbab09623babd81a94efd8a2ef1dce194e6cb99dcbbb0de4353011f2cb20495a7c00b10f32ef0dc46bdeeef0fd865d6ff6a917f56cbeda64f915d3cf29371b3b4c86be9779bf6416be691edaf96740b0177f04b549b2c82e0a2ffb08b0a5634b81cf81fffa00a99de5bf049e7f1e9b344b7066471fc117491282ea38e918945680922c1d7a59b6cfecf4bd669cfc11956111105af5812e58e06de9d36f44eeffa1de404bf0c84ef34f8216f6d64be2e15950df4bedff5037e049145e965feeae76673e03336c94ea6f3847efc0e5859be4bfe2469db60e51e47e0c310cc0600fc34b2e194ed22dfe12c4c4d1fa423422ddbae167883244f68896fab613f48ab91db36527c83d54f46aaac2b3437c5ad5582a6d25f26de4db5fe9eedb55273b2fb3b137cc0a94ec7a2d69c9161bff310c0aaf571f157992f550c2899d21bf5fd3583ca75579bfce3ba45e16445e7cd261d2838b4ea8127b4591d9a887579237caa3ad4a9330ec95b30466957928c97a4439ed2bf336a1878ad3a967650c46dad02e922840e75a10ce92323dd7e21d82c24f92edb9367cb62f8c8639b2e3734d54499ca92ffc73f5699a259b61683b5e78ae39afcef6965739b2c370180671796001727be36541d2ab0a621c7a9b30c07e6f25f3224349dce3331a7a38256e5ec53d3240b9f0f27e6f1423efe0212fde9d6b2ae3a0872b74514748ba2a58eefaff5d4f16cb1866e67b36152532c364b821d449864142ade3a0de9deb6ec304b7ba9484c9c8d2d095809f611d1aa08f45d3da58e1edf3902013d55e00fc0cc99698da44d848c5df6552786e9a0571613bc4dead373fd8b3fca2489947f25f23246d2583e89bbaa19da32038db0225e1dd16882426f1bbcdf96647db62af081a1cc1804cb3848487a0adccc2268a95e46481eba7b2366dde896c5141254fd83ba4edc330afe2c2064ea0dcdc3d0d114e9852a3a8ce05c9c20091080a55781d3337e1b39b01655bf8e9f40265c6c1cd00a6392cbc28a5d1b35eb9b6dca0b646b08c0394b8ccd3b02c36fc5dbffcb52efe5dd670c0b1839bc1ce8bdd241be224b463fc25c9f0f030a4fe4cbd1108dce7a0d224acf81137fe009a740d1ef267e11a77e90270cb354dd8e933b01fe00bace5c6f9d08df3c8cb731bbf8770cbbbf01f2e8bfc337069961caa0f0085a19fda687b012a7063fb9de6bc6ae20be75a0933e51e2a336fe8046e90d5271bef8216991de7e05f5c026a580d3afc0c5c5cf7b7f7ec2d44939fbdbc680f1ce2320cebaaf674a0ae7aac4f61eeff3df8850398473879a14724678f74e4e431714faa8738f952c728e544f3b23c20672afc177e32f8e79f7f6e06a04c3e7792740f8f7fc01630246721d0da1e31c18114f4e27a850d6ed0fdbf0771775ec4c082a2397a83fbaf5f6fef6e061188fbfdeda87efc0b14d2e07e2070c2dd1f3cf7073f79e6b97be1eefe96ff7237b9e56e79feeed6820d28ffcb05026cec30f788ba81b1bf79bbc1fddd98136e6f064a9c0cee799ebfe5c7c2cd601906f17670cf13c27b83fbd148e06e6f062f813bb8e76e0632fd35fefa2bb55d8e3caf5ce88dbb19ac1974c5705b637fcb4d483141db7c700f034e8b200224d61e1adcf37f4e845b6e7cc7f13783650e357f72dcd73fb9db09f7cfcde0f132683bd37f6e06d2e7418dbffe2ae332f7dcc1fdff7037dc0df72fb2be70c670f608b05dcdd3b3c0eea88f81787bdcd71dec7587793573d3b33cba38fdc33cf638ae863e918cfa34a6139cff6b6489eed120d25bfc6b47aaef0ad63f3703d72eecc1fdc0db2758910f3b5398e58afc847faec5a3a51fa28769b250a469ac54e84e0aa65891c4ed432c665685a11ec39f13cd0aeb998b2d79f2fa60689cadefe3876942da1449e451b49fa8829bbab2cf9bc1f8d511b89d1d2f774e340e5d89cf6d5d3d3e08ab9d396aea265b47782c5c63953a12caeb71a75891fdd0994f279bf9e16b5b274db129af7666c5f3ae1ce6a624ee5134116c7d15a24af495efe9d111c6a5653c615b1f7396a146a6be0c15d9daa140c480331226a513699c29eddb39c1afb916174d9bf27df6b45e8b8017b604adb40cd577e549a5cc5789693c61d758c2784777aef2e668c5a348db2af2ca77e5ef30eed19535df92c49d1588af8ec017963ee65075db8e0773626816d9fa21ace9ca1f5df9a974653f459c953af24b438f94fe668afc542279f66a0bb3d85a8f47b6b14a3463e95b82f662e9fcde91679cb5eed64b157aeb9c3a86b843f113568297c406f891ca2d8cc740059e90a678b1de2f60ac768ce7e44e99e7b43ff4ea44da483d2677808715dd26bdf591c5d2325cacc8cbcad2679c653c62730d75a8ebef2969717b32d4d8d5f9d08957c71fb8ab5f04626c1b56e804222674d1a76cdbd119699529bc6073ae558e3cc55634a914b95dfbc28cb5d2920f210a44df899eb02bfba1228705922795dbd27dfab7228b89a51ff6a6247296e173ed3ca4049b32d05f2b4d9d0f4d498c9c91d2ef47da634bd622d3d07277fe885df92b36a383ef4439c547f54da1189986ba55e4e5ce3144df95c3c0d60fa93bdfb2f3c16ea4554808774e74712ea9132f39533fe40f92b8b574cb77f503872a91732a113bfa0cf8ae3225f16fd758728a7c486de10533b217b8fa78ebc85a4565fbeb66becf9559b3b6536c49631f45285185b1efe82f318cf3103e9568b4aa6c7d1c036fbc811d6915c82df083268d412652274229c3dfdb87685659158e6d59cbd12761ad28cc2dfd3c1e0fd23456ab2da32b9e4ad350e385e40a96a11e6d7d52aad51ee6f3f2102f391485a555edb12af03e1aad421474631aeba61fc2a709e1b7f51ebb860a7426fcf1d093512e86bedb357be5e286f66ab5bdfb81dbb9fcd9f153a337134cfb6fe9a8d031baf7ea3f42ab962e945740e780dc091ab7982f4347367345725bbed064ad40f2c177e597864658a5ba9dc585e891f992b3a4716c194f0912b4dc0af6581d69475377c34bfd746b057f6ea34fe237f8eb2666d6102bcf1c562bc4cc7b8a419f5afa2da1b522e558adbec6f0cbbcd787079a082f04be8707e8816fc9db7a46867f04cc1af6e9dff2cc8fa07ba67b2181d93cb57d664a4df7d21542cefe1e826cf88e7c38ab7b612fb52385ee09b3dc91a6e1027095c2e393f492b43c1484c746ff32ba1e032deab5f958cfc3588e3c7935f53d56d73e99f3c278fc94ce8731bbf9d63afe65a4051fe8f96757574f75fdded49719f090322736c0ce35a8fe9d8b95a5832eb2526ba45596fec4d065c223d81bd622d7cecd782ca596a74411452eefe85ae9ce1fefba7ad0e16eea465a0938c19ccd0ff5fb7f48ef09c43e791f76c6b53aa6a1e742263c9d53db26fdf1daf198d7ad05566ab83bfadbe9b9462f7c4bea5fc686d9d07d5b9144c22beb86b6b355e818626e1aab7031679e7bfbfa14db67e07b322d8791c2bcaf48df278a3ce61db9c3419144ce96b592c597d27bd1af83fac757a5959f33edb28899b56cfe42c4d289fc89d8943bfb80fccd0f5f37d23464f165fbb7f4c3d15a835ea3b8cfb9bce12922d7b3377a3071bb67583766bdc49a77b5ff8d74a3f8317240ff8016e2df8d1dfbf65dedf8193a83ac2bd20b66e6cce21a3af365a848a8b5fb3784e7c509d521b132029f01ddfd08e89e118b600b818deaa8d512f6d9d858d7b6ac156ba559d5fa6d4d781ac7a63c0e5d618aed5aa71c6d7952b9b24f6cb9da3eeaf4cc434460a9dc897b5b3837ee0bd8fca3163f6112d891f6ea4a88d8b18ea0febd905c0d7c0adb58a560b7d6e3809fa211fb1dec0ed6bf80772c7dc9a16a9a98f31c6ff469fad0d81532edffdb59db197448e4043886f2395bb9e9fb41127768be4a1dd04bf2615ceb540c76e7d11ca969dd768b4d7dbc55642bb5f4c31655285766c5c73657dfd60a1d79756c6da6a8a14fe8b6fa0cd6a4f2893efdb946a0f7225bd7726bfe38f1465c2d1395b833a33434474f7dddf28ece6ac6adf766d257638b4d589954ab25e18f55832743430df61659ecb59b925838c22a85fe5120eec83e1b855ba0716dc337b422fee3de3254ce5b137fcd77c06798aba1a9afc6eddae8dd7a038d74a1b5371bbcdb7da0815b689dfe273ae57b33a6dfbc734ab3ec47c0d88c0d8e30573aaf4ede88ac317a0bbdc2b8c05bd45ea07dae3e296fb0de17e5adc191c89129317cfe0a3c5edb44cddc894c56b5ee52ab65e4eae357ba663b334a129587b8c12175e510fc68e25b816f4e7d41e04fbc6efd16b1b08c55e208aba322f9a42f570e774efc881dc16cd70555db4607101856473734f81100cf5be542728126d81ca921e864123b20fc2d76fd077bec8c440e6c41f0b9bc75cd638abc0a91b0ac6ca36e53e4596949a2cfd827c00bce27ec04c6969855d6a8e321d3502bd3d8a6a7be09d9f3bfd5fe07f01f3af28eb1de82befb53915c0677f429f9abfb48eadf6e2f646c8969ac8c40e7f80ac8fd7b7247f4b63cc58e3ed9daba955a86829d9115a2584d4157113b7f5dfb39b0177a73902713ec42df954416ef4fe92e862e0c1fd7ba89da3558152cde8996648dd42aff981ee7e70d76e7bbfa06e21748f870de2c6f7c6adef57ab4f3f651acfade9ad1c5f30eb7854c7887ea92694cf6d311d917e2c5b167a7001ec71f01e1d5535d4269835e89ae9aa9a10b7e4a805afb5fe5c95c70af3deacf9d91e166ee9c6788a1224f225a164cfdc05b6bb1b20c889b8d434b12036f0df6cf8c53e46ecddedbfbccb5b8b58ce52b8ac2fd39dab9f3706fad2fef779fe5ab9748e35c432d17f3d518c9addd3e512bf4097a4f6344df57fbf5e0f7427f77f4978e79595637edda736fed2dc12a19dd4ff84aafa8bdc1737851c1dc81b68fd46f253a29510531722eeb7dd2d7898d15398ccea7fb09e8d3762f32dbb8de13d5b993c006d98118af1c72b6ae1d694cf615451ac4c54aab62e5a1af4fe9b85ddd45bbca07ffaea55b634b5ed6f5cc78d21ebbf22c031d4d79b68941e64d99f0983e2d95ef939a07a359eeccb7d8369eba718559a9cc573ec4e0ccb5d8c50ae52976a359eeea2fd46e134327d60a53e24314cd386b2dee9dd10a62a229f8f235ac767c60e2a32bc3f7c19fb00cd6177ec7f667fcdc36be466d5ab2ae158ead68b27b1b2fdbe63d9f491a3334021b18f636d547c11b39ca618fb4a219efcc9f26107f762a6a2f3478c84f25c323c4a658486e601a49bb07129a445af500fe46ac15b0d73daef7d5c3b765a707a529369e92b77e20f1fff658df27fd7957607343fce7adec3f4cdfc2beab4bcec0b6ebdbe915df89b7e761f531ef186ff7fb06f6dd9824c187893b7fe34bb78e07373422e5c533ab6fc426fe457e197c886efe11d4bf4c7d1b5bfe1174cf4c3b1d2bc1741e0dae9306066c3e144d8a176199583aefbf2f9b56e508fcb309b1147d761a4b021ec38c7ec50ccf802cfab6007c4ace7d9e9fb95b6cce97afb6ac15a63e25f1f8c64f32a3171a8322fa16621d9133520b13f491705bc7dce7ab84e841862f19d99a79f20cfcff101d93c646a87de72e56dfce4bdfbfcfeb56acee1c8de2b9ee64cfab9af598c68d8da856e284e5f5b7f2cbd2ef7b278ff31c13de7d4eb03a6ae4f9ac5ce2cdfaa29cf7d69191d13f292def14c92d5d9d0f2c43891b3efca9e587876f4afec873fdfe675c3b17531631e59bff4c3c6ed4d0b495bfc667392757fd793571b9867fd6db9ebdf85eec1ed1f721de40d7a0e9bff3cf02e27f008e711b9760d6f0649fa6ed67f76a5817acc8e15191bf6324f83b14af12eafb93f34d96cffa7edaacb28e9d9f760ad7da1123cb77e65af8abbe1ac4886cfd96f5d96a3bcf78c48bb958d93a0fbe9e60198f69736e43f7c03bc6e78d1501f623c6e624e5c646009be13b067cdbf84a7dc6da9c1512f9f9b598492b0f0d3e7ddf6d4478fc1cffe15ff3efda715a38531f8fd91801cceb8c9dd9935927d6724722bcd9d2ef43bf469ab6ba80fcb2b2d3ee2349f72c9db533899d0563327666bdc65a4ed7cd7599f30a62cb3dc49defb8905c82b3298929b1c1e6cbc419a960f783ff4c6200e6bce6bf137b5330f53d13d36360688ce1a1fa8a3509cedac79ca98725aac833c4bf89cfd5e8778b3de327b4aee3a1da893dd9ac219503183f797b96d8c930f56f8057b0d2c2289d9e9ad3ba635f1f37fb0bc4271a1d0a7bee436cf98ee4f7f809cee118b9adfd48b29ed3a0b19514e65cb3a983fda4796ee3dd72083674b356cdd88dee4a9d78155a8256b5348fc6bca3ab210a18ff5850778e70084dfdf6b77036a3c9ce9135df09183ac96d9fb0af048e30c92d7d56767843fbbed3636d1f2c5e8d6ea8f508f0dca26fbfc19929b3c7111d42748722a9cd9e1174e7cb4c4c53729be798eaccc6df8178cdefd1a17e971daff6f761ef1666158a666395f2793dbf59e9ad7f6b6e608ff6c671e22dd019ea7bf3a9f5cc6f8d41ec4f960f55819c75c4940ff32ec7472c6dbd594bcb69e00c36064362388fbf4557c66e64e74cf412e0c2b4b7e718bd98d1893fcdd2c7d5c76037b2f1445cd78d711b1f0479a944bfef5b36718ed9ab29687bd04db00f30f19136ef85e86a63891f24317c88109c6f92b9d3b1135d3859133656a471a9299d898b9cf139189b887722f0857d220b087219823adfc08e2629c4fc94f91e83ad4afbea9d19d73e4cf8d362634602e5e59749644a6ddc268038bca9df628a03b6e5496ed53180d091359a2f55f3952dbf604b0805c813306b9dfd8626ddd87dfe58e8d3cfede7cc3eccf445e840e289a02f80aecffb101d6f63723646f7d2377ae7fb24a2fb0689179a8dadf2fd3c0d9af8756ba7d058ffbb3c017108f9a5b4aa5b6cf6ecdc553bff46dfffcefc1b7c9e053321e391f8f2346eea7f4adaf1678020afa4f31b9973086ba4ee5c63dac906f8e20d6d65b772461a9cc7ef5da38d116e1d6159cb87acb5e758648f829caa75edb75a9218a368c223698f1d39ccacf5347812264417b8fa927346ea5109c41d0aa6c1731b9384ba5bfca4afb68a1cc29ebf2363d29801d8b10d0d7a3c05784ba87c086ee345736e52cf2b51397e67c9219ce154a842a929dde2953e7bb55bfc887d5359fa786b192ac4377d6aeff066bc0ca9ce69e2c0ec5994ef44d487ecd63e75a255e8d5733ed238590a7aa339138239598206e7eed5034ebad847774645e8f736bed3c4931bbc69ce088d9fd3f93639086d6c5cad963b14911caa33b9212276462acc1b6c9e10f0a4b91f3b0471477d5232737b67dd678523b4b1b71dbc8fe8598f0d3cc3d8fe286870df6317ecf0a0a53becf3d4f6aae50afa516431f4e6626eebcbd4959bf3c7278ab3522adf675b4b125357223975b5eef9051ea1fd248feb103f04a24ccff9ea98b93ce15d49f4299df6ce0870007d2b468e70c89d11027ed821c8d9936702f0a4224f4a6b7dbbe872563a7d8ee4c911ceaa611f6ae4b995fb469ee54965c95ab560e8df9c8f505cef7a6bc3c8318a20ef50ab185d46e9d9c933c41c1b38b3b6bf217f8a43676847f3141357e7614f8438cfd6d4d5dc5a8b4bd350334b9e8c1c9aa305b9b254afe226d781cd0f3565b1cb6b6c7199ee1e2ac8d95d1e1fa2b731194b1a53de50da1c2845720bf0171ea2436ab6be2beaf2af20bed3ee7153fc2440ae527d36f023607090a6c54b352dcc6a5a386b8ac31b5c56bd78077d06dbe0f853eb7cb85657438e42175b6decce564fd771cf7aef6ed600ce24166d7eea347e0826cd9c4b470e83c6f751ab6dd9bc037bf8e629f9ef01a49c67f0e9689b74dee594938cf40f73d0ef876162bb9ffd94e304b6f99483e7bef29ff894839bc0a71cc2f8cb98fb73722b7cfdf52f39eefe135f72d4d8bef325c7d7b31f72f093afe3e6938b313fe2c9bff31f72f440e93cb9f31f72bc07faab1f729c7ebf71bdcbed7a97dbf52eb7eb5d6ed7bbdcae77b95def72bbdee576bdcbed7a97dbf52eb7eb5d6ed7bbdcae77b95def72bbdee576bdcbed7a97dbf52eb7eb5d6ed7bbdcae77b95def72bbdee576bdcbedff89bbdca85ef9adfba74e0ef6ba6ba79aeb29e02852f9c6d59f28cc97afa67e9b407a091c4dd363d1dc1196be2389be6be0c4110edb0749cc2c639b2adfba14725b37311aadb690d260c2b1334f8efa135bd0c6902ea954282569047a77f5127cf68062adbb0e805e29d2f61585c7679aeab2805483b0c82c237417ebb79fe8035e0af954fea944806fe0c331ad03e31bebee8a9ffaba0171e7c84f34d5995ee31241dad33e310dedf681d6356936cda7cc4ff03907e0116c734586d4d765d85cd1f4507dc528d64a570e0babfb2c2e38fdccb9bd1aa34e97269fcc9a755a4ae48c3448bfddba064923c3960163904fb2e013d8053d56877488003e6574e72b1f52ddad3a45a24e45618ef901af0e27a04792d31492eeba0bb84a82a695fcacaf85c19bf91e2b06b992a64967524c63293af29ef0c603fd8ca55907655e4c282debeb08daf45d66fcde6730f0e99c9522a1bd82e9aebe9ae174ccc7f3e349ae601bcbd48c0ea15a355720b889adafb696dea510f63fef237d933429ca4ba4cf2ee58e5e3dd17d3200a91eb923ccb68b395ca1d05d27d4c80ef0ca02d2e5a43d063e5baca7940e94ae125c45f454dac6f2274d1948905cc4ba50849ec69d9307e0cb535e4dada0cf8f6b7956f322c10bd2001fc9553d1487480de0ea05d761eb8df574f2733d8d6ddd2d9d910a743b2b43efcae2afc935cc033ea7afe5ef39c1ccbcf1a29a6e1f6295279f2ce82f8426b54e099dc7e714e47b72168f3a05f4680ab392ac09ff913ec8311df30ef482234f7c4bf2096da85e60ae13a27c04ebf92d5ffc966e009caa6d0ef2007a61f39c2f249ce6b6ae34639377e17a332d9a550bc9dd18ecf57bb309498151ab6d2ae1fffe300da4ce1df0aec903d7e4816bf2c03579e09a3c704d1eb8260f5c9307aec903d7e4816bf2c03579e09a3c704d1eb8260f5c9307aec903d7e4816bf2c03579e09a3c704d1eb8260f5c9307aec903ff1f250ffcf3bf000000ffff0300d954f3bc53880000`)))
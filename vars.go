@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/jsonnet-libs/docsonnet/pkg/docsonnet"
+)
+
+// jsonnetVars are the flags for external variables and top level arguments,
+// mirroring those of the jsonnet binary
+type jsonnetVars struct {
+	extStr, extStrFile, extCode, extCodeFile *[]string
+	tlaStr, tlaStrFile, tlaCode, tlaCodeFile *[]string
+}
+
+func addJsonnetVarFlags(fs *pflag.FlagSet) *jsonnetVars {
+	return &jsonnetVars{
+		extStr:      fs.StringArrayP("ext-str", "V", nil, "Provide external variable as string. If <val> is omitted, get from environment var <var>"),
+		extStrFile:  fs.StringArray("ext-str-file", nil, "Provide external variable <var>=<file> as string read from the given file"),
+		extCode:     fs.StringArray("ext-code", nil, "Provide external variable as Jsonnet code. If <code> is omitted, get from environment var <var>"),
+		extCodeFile: fs.StringArray("ext-code-file", nil, "Provide external variable <var>=<file> as Jsonnet code read from the given file"),
+		tlaStr:      fs.StringArrayP("tla-str", "A", nil, "Provide top-level argument as string. If <val> is omitted, get from environment var <var>"),
+		tlaStrFile:  fs.StringArray("tla-str-file", nil, "Provide top-level argument <var>=<file> as string read from the given file"),
+		tlaCode:     fs.StringArray("tla-code", nil, "Provide top-level argument as Jsonnet code. If <code> is omitted, get from environment var <var>"),
+		tlaCodeFile: fs.StringArray("tla-code-file", nil, "Provide top-level argument <var>=<file> as Jsonnet code read from the given file"),
+	}
+}
+
+// ext returns the external variables as strings and code
+func (j *jsonnetVars) ext() (vars, code map[string]string, err error) {
+	return parseVars(*j.extStr, *j.extStrFile, *j.extCode, *j.extCodeFile)
+}
+
+// tla returns the top level arguments as strings and code
+func (j *jsonnetVars) tla() (vars, code map[string]string, err error) {
+	return parseVars(*j.tlaStr, *j.tlaStrFile, *j.tlaCode, *j.tlaCodeFile)
+}
+
+// mergeInto adds the variables and arguments given as flags to `opts`,
+// replacing those of the same name that were already set, e.g. by the config
+func (j *jsonnetVars) mergeInto(opts *docsonnet.Opts) error {
+	extVars, extCode, err := j.ext()
+	if err != nil {
+		return err
+	}
+	tlaVars, tlaCode, err := j.tla()
+	if err != nil {
+		return err
+	}
+
+	opts.ExtVars, opts.ExtCode = mergeVars(opts.ExtVars, opts.ExtCode, extVars, extCode)
+	opts.TLAVars, opts.TLACode = mergeVars(opts.TLAVars, opts.TLACode, tlaVars, tlaCode)
+	return nil
+}
+
+// mergeVars merges the override strings and code into `vars` and `code`. A name is
+// either a string or code, never both.
+func mergeVars(vars, code, overrideVars, overrideCode map[string]string) (map[string]string, map[string]string) {
+	outVars := make(map[string]string)
+	outCode := make(map[string]string)
+	for k, v := range vars {
+		outVars[k] = v
+	}
+	for k, v := range code {
+		outCode[k] = v
+	}
+
+	for k, v := range overrideVars {
+		outVars[k] = v
+		delete(outCode, k)
+	}
+	for k, v := range overrideCode {
+		outCode[k] = v
+		delete(outVars, k)
+	}
+	return outVars, outCode
+}
+
+func parseVars(strs, strFiles, codes, codeFiles []string) (vars, code map[string]string, err error) {
+	vars = make(map[string]string)
+	code = make(map[string]string)
+
+	for _, s := range strs {
+		k, v, err := splitVar(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		vars[k] = v
+	}
+	for _, s := range strFiles {
+		k, file, err := splitFileVar(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		vars[k] = string(data)
+	}
+
+	for _, s := range codes {
+		k, v, err := splitVar(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		code[k] = v
+	}
+	for _, s := range codeFiles {
+		k, file, err := splitFileVar(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		code[k] = fmt.Sprintf("import @'%s'", strings.ReplaceAll(file, "'", "''"))
+	}
+
+	return vars, code, nil
+}
+
+// splitVar splits `<var>=<val>`. If `=<val>` is omitted, the value is taken
+// from the environment variable <var>.
+func splitVar(s string) (string, string, error) {
+	if k, v, ok := strings.Cut(s, "="); ok {
+		return k, v, nil
+	}
+
+	v, ok := os.LookupEnv(s)
+	if !ok {
+		return "", "", fmt.Errorf("environment variable %s is not defined", s)
+	}
+	return s, v, nil
+}
+
+func splitFileVar(s string) (string, string, error) {
+	k, file, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("expected <var>=<file>, got '%s'", s)
+	}
+	return k, file, nil
+}